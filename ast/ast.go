@@ -59,10 +59,14 @@ func (ident *Identifier) TokenLiteral() string {
 type LetStmt struct {
 	Ident    *Identifier
 	InitExpr Expression
+	Const    bool // const NAME = expr;
 }
 
 func (ls *LetStmt) StmtNode() {}
 func (ls *LetStmt) TokenLiteral() string {
+	if ls.Const {
+		return tokens.CONST.String()
+	}
 	return tokens.LET.String()
 }
 
@@ -76,9 +80,16 @@ func NewLetStmt(ident *tokens.Token, expr Expression) *LetStmt {
 	}
 }
 
+func NewConstStmt(ident *tokens.Token, expr Expression) *LetStmt {
+	stmt := NewLetStmt(ident, expr)
+	stmt.Const = true
+	return stmt
+}
+
 type ClassStmt struct {
-	NameIdent *Identifier
-	Methods   map[string]*Function
+	NameIdent  *Identifier
+	Methods    map[string]*Function
+	MethodList []*Function // the methods in the order they are declared
 }
 
 func (ls *ClassStmt) StmtNode() {}
@@ -92,7 +103,8 @@ func NewClassStmt(className *tokens.Token, methods []*Function) *ClassStmt {
 			Token: className,
 			Name:  className.Literal,
 		},
-		Methods: make(map[string]*Function),
+		Methods:    make(map[string]*Function),
+		MethodList: methods,
 	}
 
 	for _, mth := range methods {
@@ -112,6 +124,11 @@ func NewThisExpr(kw *tokens.Token) *ThisExpr {
 	}
 }
 
+// Keyword returns the `this` token
+func (te *ThisExpr) Keyword() *tokens.Token {
+	return te.keyword
+}
+
 func (te *ThisExpr) StmtNode() {}
 func (te *ThisExpr) ExprNode() {}
 func (te *ThisExpr) TokenLiteral() string {
@@ -135,6 +152,22 @@ func NewAssign(name *tokens.Token, value Expression) *Assign {
 	}
 }
 
+// CompoundAssign is target op= value, target is an Identifier, Get or SliceAccess
+type CompoundAssign struct {
+	Target   Expression
+	Operator *tokens.Token
+	Value    Expression
+}
+
+func NewCompoundAssign(target Expression, operator *tokens.Token, value Expression) *CompoundAssign {
+	return &CompoundAssign{Target: target, Operator: operator, Value: value}
+}
+
+func (ca *CompoundAssign) ExprNode() {}
+func (ca *CompoundAssign) TokenLiteral() string {
+	return ca.Operator.Literal
+}
+
 type Logical struct {
 	Left     Expression
 	Right    Expression
@@ -149,16 +182,21 @@ func (lg *Logical) TokenLiteral() string {
 	return lg.Operator.Literal
 }
 
-// ++ or --
+// ++ or --, a++ returns the old value of a, ++a returns the new value
 type DExp struct {
 	Left     Expression
 	Operator *tokens.Token
+	Prefix   bool
 }
 
 func NewDExp(left Expression, operator *tokens.Token) *DExp {
 	return &DExp{Left: left, Operator: operator}
 }
 
+func NewPrefixDExp(left Expression, operator *tokens.Token) *DExp {
+	return &DExp{Left: left, Operator: operator, Prefix: true}
+}
+
 func (dp *DExp) ExprNode() {}
 func (dp *DExp) TokenLiteral() string {
 	return dp.Operator.Literal
@@ -209,9 +247,39 @@ func (call *Call) TokenLiteral() string {
 	return call.Callee.TokenLiteral()
 }
 
-type Get struct {
+// Spread is ...expr in the arguments of a call, the elements of expr are passed as arguments: f(...xs)
+type Spread struct {
 	Expr Expression
-	Name *tokens.Token
+}
+
+func NewSpread(expr Expression) *Spread {
+	return &Spread{Expr: expr}
+}
+
+func (sp *Spread) ExprNode() {}
+func (sp *Spread) TokenLiteral() string {
+	return "..."
+}
+
+// KeywordArg is name: value in the arguments of a call, it is passed to the parameter named name: f(b: 2)
+type KeywordArg struct {
+	Name  *tokens.Token
+	Value Expression
+}
+
+func NewKeywordArg(name *tokens.Token, value Expression) *KeywordArg {
+	return &KeywordArg{Name: name, Value: value}
+}
+
+func (ka *KeywordArg) ExprNode() {}
+func (ka *KeywordArg) TokenLiteral() string {
+	return ka.Name.Literal
+}
+
+type Get struct {
+	Expr     Expression
+	Name     *tokens.Token
+	Optional bool // obj?.name
 }
 
 func NewGet(expr Expression, name *tokens.Token) *Get {
@@ -221,11 +289,48 @@ func NewGet(expr Expression, name *tokens.Token) *Get {
 	}
 }
 
+func NewOptionalGet(expr Expression, name *tokens.Token) *Get {
+	get := NewGet(expr, name)
+	get.Optional = true
+	return get
+}
+
 func (get *Get) ExprNode() {}
 func (get *Get) TokenLiteral() string {
 	return get.Expr.TokenLiteral()
 }
 
+// OptionalChain is a chain of calls, gets and indexes which contains `?.`,
+// the whole chain is null when the object before `?.` is null: a?.b.c(), a?.b[0]
+type OptionalChain struct {
+	Expr Expression
+}
+
+func NewOptionalChain(expr Expression) *OptionalChain {
+	return &OptionalChain{Expr: expr}
+}
+
+func (oc *OptionalChain) ExprNode() {}
+func (oc *OptionalChain) TokenLiteral() string {
+	return oc.Expr.TokenLiteral()
+}
+
+// Ternary cond ? then : else
+type Ternary struct {
+	Condition Expression
+	Then      Expression
+	Else      Expression
+}
+
+func NewTernary(cond, then, els Expression) *Ternary {
+	return &Ternary{Condition: cond, Then: then, Else: els}
+}
+
+func (t *Ternary) ExprNode() {}
+func (t *Ternary) TokenLiteral() string {
+	return "?"
+}
+
 // Set classIsntance.Property = value
 type Set struct {
 	Expr  Expression    // class instance
@@ -286,6 +391,29 @@ func (sl *Slice) TokenLiteral() string {
 	return "slice"
 }
 
+type MapPair struct {
+	Key   Expression
+	Value Expression
+}
+
+func (mp *MapPair) TokenLiteral() string {
+	return "map_pair"
+}
+
+// let m = {"a": 1, "b": 2}
+type MapLiteral struct {
+	Pairs []*MapPair
+}
+
+func NewMapLiteral(pairs []*MapPair) *MapLiteral {
+	return &MapLiteral{Pairs: pairs}
+}
+
+func (ml *MapLiteral) ExprNode() {}
+func (ml *MapLiteral) TokenLiteral() string {
+	return "map"
+}
+
 // slice[i]
 type SliceAccess struct {
 	Name Expression
@@ -305,6 +433,28 @@ func (sa *SliceAccess) TokenLiteral() string {
 	return "slice_access"
 }
 
+// slice[low:high:step], each part is optional(nil)
+type SliceRange struct {
+	Name Expression
+	Low  Expression
+	High Expression
+	Step Expression
+}
+
+func NewSliceRange(name, low, high, step Expression) *SliceRange {
+	return &SliceRange{
+		Name: name,
+		Low:  low,
+		High: high,
+		Step: step,
+	}
+}
+
+func (sr *SliceRange) ExprNode() {}
+func (sr *SliceRange) TokenLiteral() string {
+	return "slice_range"
+}
+
 type SliceElementAssign struct {
 	SLA   *SliceAccess
 	Value Expression
@@ -322,3 +472,71 @@ func (sea *SliceElementAssign) ExprNode() {}
 func (sea *SliceElementAssign) TokenLiteral() string {
 	return "slice_element_assign"
 }
+
+type PatternKind int
+
+const (
+	PatternLiteral PatternKind = iota // 1, "x", true, null
+	PatternRange                      // 1..5 excludes 5, 1..=5 includes 5
+	PatternBinding                    // x binds the value to x, _ matches anything
+)
+
+// MatchPattern is one of the comma separated patterns before `=>` in a match arm
+type MatchPattern struct {
+	Kind      PatternKind
+	Value     *Literal      // the literal, or the low bound of the range
+	High      *Literal      // the high bound of the range
+	Inclusive bool          // the range is low..=high
+	Name      *tokens.Token // the binding name
+}
+
+func NewLiteralPattern(value *Literal) *MatchPattern {
+	return &MatchPattern{Kind: PatternLiteral, Value: value}
+}
+
+func NewRangePattern(low, high *Literal, inclusive bool) *MatchPattern {
+	return &MatchPattern{Kind: PatternRange, Value: low, High: high, Inclusive: inclusive}
+}
+
+func NewBindingPattern(name *tokens.Token) *MatchPattern {
+	return &MatchPattern{Kind: PatternBinding, Name: name}
+}
+
+// IsWildcard reports whether the pattern is `_`
+func (mp *MatchPattern) IsWildcard() bool {
+	return mp.Kind == PatternBinding && mp.Name.Literal == "_"
+}
+
+func (mp *MatchPattern) TokenLiteral() string {
+	return "match_pattern"
+}
+
+type MatchArm struct {
+	Patterns []*MatchPattern
+	Guard    Expression // x if x > 10 => ..., nil if the arm has no guard
+	Body     Stmt       // *Block, or *ExpressionStmt for `pattern => expr`
+}
+
+func NewMatchArm(patterns []*MatchPattern, guard Expression, body Stmt) *MatchArm {
+	return &MatchArm{Patterns: patterns, Guard: guard, Body: body}
+}
+
+func (arm *MatchArm) TokenLiteral() string {
+	return "match_arm"
+}
+
+// match (expr) { 1, 2 => "small", 3..10 => "medium", _ => "large" }
+type Match struct {
+	Subject Expression
+	Arms    []*MatchArm
+}
+
+func NewMatch(subject Expression, arms []*MatchArm) *Match {
+	return &Match{Subject: subject, Arms: arms}
+}
+
+func (m *Match) StmtNode() {}
+func (m *Match) ExprNode() {}
+func (m *Match) TokenLiteral() string {
+	return "match"
+}
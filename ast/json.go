@@ -0,0 +1,423 @@
+package ast
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+const (
+	ErrUnknownNodeType = "unknown node type: %s"
+	ErrInvalidNodeJSON = "invalid node json at %s: %s"
+	ErrUnknownToken    = "unknown token type: %s"
+)
+
+// nodeTypes are the structs which can be encoded, the key is the "node" of their json object
+var nodeTypes = map[string]reflect.Type{}
+
+func init() {
+	for _, node := range []interface{}{
+		&Program{}, &Identifier{}, &LetStmt{}, &ClassStmt{}, &ThisExpr{}, &Assign{}, &CompoundAssign{},
+		&Logical{}, &DExp{}, &Binary{}, &Unary{}, &Call{}, &Spread{}, &KeywordArg{}, &Get{}, &OptionalChain{},
+		&Ternary{}, &Set{}, &Literal{}, &Grouping{}, &Slice{}, &MapPair{}, &MapLiteral{}, &SliceAccess{},
+		&SliceRange{}, &SliceElementAssign{}, &MatchPattern{}, &MatchArm{}, &Match{},
+		&ReturnStmt{}, &YieldStmt{}, &Block{}, &ExpressionStmt{}, &Function{}, &IFStmt{}, &PrintStmt{},
+		&WhileStmt{}, &ForStmt{}, &ForInStmt{}, &BreakStmt{}, &ImportStmt{}, &ExportStmt{},
+	} {
+		tp := reflect.TypeOf(node).Elem()
+		nodeTypes[tp.Name()] = tp
+	}
+}
+
+var patternKinds = []string{
+	PatternLiteral: "literal",
+	PatternRange:   "range",
+	PatternBinding: "binding",
+}
+
+func (k PatternKind) String() string {
+	if k >= 0 && int(k) < len(patternKinds) {
+		return patternKinds[k]
+	}
+	return fmt.Sprintf("PatternKind(%d)", int(k))
+}
+
+var (
+	tokenPtrType    = reflect.TypeOf(&tokens.Token{})
+	patternKindType = reflect.TypeOf(PatternKind(0))
+)
+
+/*
+the tree is encoded into an outline first, and the outline is written as json or as an indented tree.
+
+a node is an object whose "node" is the name of its type, followed by its fields in the declaration order,
+the fields which are nil, false or "" are left out, and an empty slice is kept as [] to tell it from nil.
+a token is {"type": "INTEGER", "literal": "1", "value": 1}.
+the fields which are derived from other fields are left out and rebuilt by the decoder:
+ClassStmt.Methods from MethodList, and ForStmt.Loop from the clauses.
+*/
+type outlineNode struct {
+	name   string
+	fields []outlineField
+}
+
+type outlineField struct {
+	name  string
+	value interface{} // bool, string, *tokens.Token, *outlineNode, []interface{}
+}
+
+// EncodeJSON returns the json of node, which DecodeJSON turns back into the same tree
+func EncodeJSON(node Node) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := writeJSON(buf, outline(reflect.ValueOf(node))); err != nil {
+		return nil, err
+	}
+
+	out := &bytes.Buffer{}
+	if err := json.Indent(out, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// Dump returns node as an indented tree, one field in each line
+func Dump(node Node) string {
+	sb := &strings.Builder{}
+	writeTree(sb, outline(reflect.ValueOf(node)), 0)
+	sb.WriteByte('\n')
+	return sb.String()
+}
+
+func outline(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+	}
+
+	switch {
+	case v.Kind() == reflect.Interface:
+		return outline(v.Elem())
+	case v.Type() == tokenPtrType:
+		return v.Interface()
+	case v.Type() == patternKindType:
+		return v.Interface().(PatternKind).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		return outlineStruct(v)
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		elems := make([]interface{}, v.Len())
+		for i := range elems {
+			elems[i] = outline(v.Index(i))
+		}
+		return elems
+	case reflect.Bool:
+		if !v.Bool() {
+			return nil
+		}
+		return true
+	case reflect.String:
+		if v.String() == "" {
+			return nil
+		}
+		return v.String()
+	}
+	return nil
+}
+
+func outlineStruct(v reflect.Value) *outlineNode {
+	if te, ok := v.Interface().(*ThisExpr); ok {
+		return &outlineNode{name: "ThisExpr", fields: []outlineField{{name: "Keyword", value: te.keyword}}}
+	}
+
+	tp := v.Elem().Type()
+	node := &outlineNode{name: tp.Name()}
+	for i := 0; i < tp.NumField(); i++ {
+		field := tp.Field(i)
+		if !field.IsExported() || derivedField(tp, field.Name) {
+			continue
+		}
+
+		if value := outline(v.Elem().Field(i)); value != nil {
+			node.fields = append(node.fields, outlineField{name: field.Name, value: value})
+		}
+	}
+	return node
+}
+
+func derivedField(tp reflect.Type, name string) bool {
+	switch tp.Name() {
+	case "ClassStmt":
+		return name == "Methods"
+	case "ForStmt":
+		return name == "Loop"
+	}
+	return false
+}
+
+func writeJSON(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case *outlineNode:
+		buf.WriteString(`{"node":`)
+		writeString(buf, v.name)
+		for _, field := range v.fields {
+			buf.WriteByte(',')
+			writeString(buf, field.name)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, field.value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case *tokens.Token:
+		buf.WriteString(`{"type":`)
+		writeString(buf, v.TkType.String())
+		buf.WriteString(`,"literal":`)
+		writeString(buf, v.Literal)
+		buf.WriteString(`,"value":`)
+		data, err := json.Marshal(v.Value)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('}')
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	return nil
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	data, _ := json.Marshal(s)
+	buf.Write(data)
+}
+
+func writeTree(sb *strings.Builder, value interface{}, depth int) {
+	switch v := value.(type) {
+	case *outlineNode:
+		sb.WriteString(v.name)
+		for _, field := range v.fields {
+			sb.WriteByte('\n')
+			sb.WriteString(strings.Repeat(indentUnit, depth+1))
+			sb.WriteString(field.name + ":")
+			if _, ok := field.value.([]interface{}); !ok {
+				sb.WriteByte(' ')
+			}
+			writeTree(sb, field.value, depth+1)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			sb.WriteString(" []")
+			return
+		}
+		for i, elem := range v {
+			sb.WriteByte('\n')
+			sb.WriteString(strings.Repeat(indentUnit, depth+1))
+			sb.WriteString(fmt.Sprintf("%d: ", i))
+			writeTree(sb, elem, depth+1)
+		}
+	case *tokens.Token:
+		sb.WriteString(v.TkType.String() + " " + tokenText(v))
+	case string:
+		sb.WriteString(v)
+	default:
+		sb.WriteString(fmt.Sprintf("%v", v))
+	}
+}
+
+func tokenText(tk *tokens.Token) string {
+	if tk.TkType == tokens.STRING {
+		return tokens.Quote(tk.Literal)
+	}
+	return tk.Literal
+}
+
+// DecodeJSON returns the tree encoded by EncodeJSON
+func DecodeJSON(data []byte) (Node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var raw interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	v, err := decodeValue(raw, reflect.TypeOf((*Node)(nil)).Elem(), "$")
+	if err != nil {
+		return nil, err
+	}
+	if v.IsNil() {
+		return nil, fmt.Errorf(ErrInvalidNodeJSON, "$", "null")
+	}
+	return v.Interface().(Node), nil
+}
+
+func decodeValue(raw interface{}, tp reflect.Type, path string) (reflect.Value, error) {
+	if raw == nil {
+		return reflect.Zero(tp), nil
+	}
+
+	switch {
+	case tp == tokenPtrType:
+		tk, err := decodeToken(raw, path)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(tk), nil
+	case tp == patternKindType:
+		for kind, name := range patternKinds {
+			if raw == name {
+				return reflect.ValueOf(PatternKind(kind)), nil
+			}
+		}
+		return reflect.Value{}, fmt.Errorf(ErrInvalidNodeJSON, path, fmt.Sprintf("pattern kind %v", raw))
+	}
+
+	switch tp.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return reflect.Value{}, fmt.Errorf(ErrInvalidNodeJSON, path, "want an object")
+		}
+		node, err := decodeNode(obj, path)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if !node.Type().AssignableTo(tp) {
+			return reflect.Value{}, fmt.Errorf(ErrInvalidNodeJSON, path, node.Elem().Type().Name()+" is not a "+tp.String())
+		}
+		return node, nil
+	case reflect.Slice:
+		elems, ok := raw.([]interface{})
+		if !ok {
+			return reflect.Value{}, fmt.Errorf(ErrInvalidNodeJSON, path, "want an array")
+		}
+		slice := reflect.MakeSlice(tp, len(elems), len(elems))
+		for i, elem := range elems {
+			v, err := decodeValue(elem, tp.Elem(), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			slice.Index(i).Set(v)
+		}
+		return slice, nil
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf(ErrInvalidNodeJSON, path, "want a bool")
+		}
+		return reflect.ValueOf(b), nil
+	case reflect.String:
+		str, ok := raw.(string)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf(ErrInvalidNodeJSON, path, "want a string")
+		}
+		return reflect.ValueOf(str), nil
+	}
+	return reflect.Value{}, fmt.Errorf(ErrInvalidNodeJSON, path, "unsupported field type "+tp.String())
+}
+
+func decodeNode(obj map[string]interface{}, path string) (reflect.Value, error) {
+	name, _ := obj["node"].(string)
+	tp, ok := nodeTypes[name]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf(ErrUnknownNodeType, name)
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		if key != "node" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	if name == "ThisExpr" {
+		tk, err := decodeValue(obj["Keyword"], tokenPtrType, path+".Keyword")
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(NewThisExpr(tk.Interface().(*tokens.Token))), nil
+	}
+
+	node := reflect.New(tp)
+	for _, key := range keys {
+		field, ok := tp.FieldByName(key)
+		if !ok || !field.IsExported() || derivedField(tp, key) {
+			return reflect.Value{}, fmt.Errorf(ErrInvalidNodeJSON, path, "unknown field "+name+"."+key)
+		}
+
+		v, err := decodeValue(obj[key], field.Type, path+"."+key)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		node.Elem().FieldByIndex(field.Index).Set(v)
+	}
+
+	switch v := node.Interface().(type) {
+	case *ClassStmt:
+		if v.NameIdent == nil {
+			return reflect.Value{}, fmt.Errorf(ErrInvalidNodeJSON, path, "class without name")
+		}
+		cls := NewClassStmt(v.NameIdent.Token, v.MethodList)
+		cls.NameIdent = v.NameIdent
+		return reflect.ValueOf(cls), nil
+	case *ForStmt:
+		return reflect.ValueOf(NewForStmt(v.Init, v.Condition, v.Increment, v.Body)), nil
+	}
+	return node, nil
+}
+
+func decodeToken(raw interface{}, path string) (*tokens.Token, error) {
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf(ErrInvalidNodeJSON, path, "want a token")
+	}
+
+	typeName, _ := obj["type"].(string)
+	tkType, err := tokens.TokenTypeString(typeName)
+	if err != nil {
+		return nil, fmt.Errorf(ErrUnknownToken, typeName)
+	}
+	literal, _ := obj["literal"].(string)
+
+	value := obj["value"]
+	if num, ok := value.(json.Number); ok {
+		if tkType == tokens.FLOAT {
+			value, err = num.Float64()
+		} else {
+			value, err = num.Int64()
+		}
+		if err != nil {
+			return nil, fmt.Errorf(ErrInvalidNodeJSON, path, err.Error())
+		}
+	}
+
+	return tokens.NewToken(tkType, literal, value), nil
+}
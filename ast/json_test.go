@@ -0,0 +1,143 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/lexer"
+	"github.com/forfd8960/simpleinterpreter/parser"
+)
+
+func parse(t *testing.T, input string) *ast.Program {
+	tokenList, err := lexer.TokensFromInput(input)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	program, err := parser.NewParser(tokenList).ParseProgram()
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	return program
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ``},
+		{name: "expressions", input: `let x = 1 + 2.5 * (3 - -4) ** 2; const Y = !true && x != null || "a\n" == x;`},
+		{name: "assignments", input: `x = 1; x += 2; x++; --x; p.v = 1; p.v -= 1; xs[0] = 2; xs[1] *= 3;`},
+		{name: "functions", input: `fn add(a, b = 1, ...rest) { return add(a, ...rest, b: 2); } fn gen() { yield 1; yield; return; }`},
+		{name: "classes", input: `class P { zero() { return this.v; } add(x) { this.v += x; } } class E {}`},
+		{name: "loops", input: `for (let i = 0; i < 3; i++) { print("%d", i); } for (;;) break; for (k, v in m) {} for (v in xs) {} while (x) { x--; }`},
+		{name: "branches", input: `if (a) { print(a); } else if (b) print(b); else {} let t = a ? b : c ?? d?.e.f();`},
+		{name: "collections", input: `let m = {"a": [1, null], "b": {}}; let s = xs[1:][::2][:-1][0:1:2][0]; let e = [];`},
+		{name: "match", input: `let s = match (x) { 1, 2 => "small", 3..=10 if x > 4 => { return x; } -5..0 => "neg", n if n > 100 => n, _ => "large" };`},
+		{name: "modules", input: `import "lib.si" as lib; from "c.si" import a, b; export fn f() {} export let v = 1;`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := parse(t, tt.input)
+
+			data, err := ast.EncodeJSON(program)
+			if !assert.Nil(t, err) {
+				return
+			}
+			node, err := ast.DecodeJSON(data)
+			if !assert.Nil(t, err) {
+				return
+			}
+			assert.Equal(t, program, node)
+
+			again, err := ast.EncodeJSON(node)
+			assert.Nil(t, err)
+			assert.Equal(t, string(data), string(again))
+		})
+	}
+}
+
+func TestEncodeJSON(t *testing.T) {
+	data, err := ast.EncodeJSON(parse(t, `let x = [1];`))
+	assert.Nil(t, err)
+	assert.Equal(t, `{
+  "node": "Program",
+  "Stmts": [
+    {
+      "node": "LetStmt",
+      "Ident": {
+        "node": "Identifier",
+        "Token": {
+          "type": "IDENT",
+          "literal": "x",
+          "value": "x"
+        },
+        "Name": "x"
+      },
+      "InitExpr": {
+        "node": "Slice",
+        "Elements": [
+          {
+            "node": "Literal",
+            "Value": {
+              "type": "INTEGER",
+              "literal": "1",
+              "value": 1
+            }
+          }
+        ]
+      }
+    }
+  ]
+}
+`, string(data))
+}
+
+func TestDump(t *testing.T) {
+	out := ast.Dump(parse(t, `const s = "a" + f(1, []);`))
+	assert.Equal(t, `Program
+	Stmts:
+		0: LetStmt
+			Ident: Identifier
+				Token: IDENT s
+				Name: s
+			InitExpr: Binary
+				Left: Literal
+					Value: STRING "a"
+				Operator: PLUS +
+				Right: Call
+					Callee: Identifier
+						Token: IDENT f
+						Name: f
+					Arguments:
+						0: Literal
+							Value: INTEGER 1
+						1: Slice
+							Elements: []
+			Const: true
+`, out)
+}
+
+func TestDecodeJSONError(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{`{"node": "Unknown"}`, "unknown node type: Unknown"},
+		{`{"node": "Program", "Stmts": {}}`, "invalid node json at $.Stmts: want an array"},
+		{`{"node": "Program", "Stmts": [{"node": "MapPair"}]}`, "invalid node json at $.Stmts[0]: MapPair is not a ast.Stmt"},
+		{`{"node": "Program", "Body": []}`, "invalid node json at $: unknown field Program.Body"},
+		{`{"node": "Literal", "Value": {"type": "NOPE"}}`, "unknown token type: NOPE"},
+		{`null`, "invalid node json at $: null"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err, func(t *testing.T) {
+			_, err := ast.DecodeJSON([]byte(tt.input))
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,517 @@
+package ast
+
+import (
+	"strings"
+
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+const indentUnit = "\t"
+
+// Format returns the source of node in the canonical form:
+// one statement in each line, blocks are indented by tabs, binary operators are surrounded by spaces,
+// and the declarations of functions and classes are separated from the other statements by an empty line.
+// parsing the source gives back the same tree, so formatting the source again does not change it.
+func Format(node Node) string {
+	p := &printer{}
+	switch v := node.(type) {
+	case *Program:
+		p.stmtList(v.Stmts)
+		if len(v.Stmts) > 0 {
+			p.sb.WriteByte('\n')
+		}
+	case *PrintStmt:
+		p.stmt(v)
+	case *MapPair:
+		p.mapPair(v)
+	case *MatchArm:
+		p.matchArm(v)
+	case *MatchPattern:
+		p.pattern(v)
+	case Expression:
+		p.expr(v)
+	case Stmt:
+		p.stmt(v)
+	}
+	return p.sb.String()
+}
+
+type printer struct {
+	sb     strings.Builder
+	indent int
+}
+
+func (p *printer) write(s ...string) {
+	for _, v := range s {
+		p.sb.WriteString(v)
+	}
+}
+
+func (p *printer) newline() {
+	p.sb.WriteByte('\n')
+	p.sb.WriteString(strings.Repeat(indentUnit, p.indent))
+}
+
+// stmtList writes each statement in its own line
+func (p *printer) stmtList(stmts []Stmt) {
+	for idx, stmt := range stmts {
+		if idx > 0 {
+			if isDeclaration(stmt) || isDeclaration(stmts[idx-1]) {
+				p.sb.WriteByte('\n')
+			}
+			p.newline()
+		}
+		p.stmt(stmt)
+	}
+}
+
+func isDeclaration(stmt Stmt) bool {
+	if export, ok := stmt.(*ExportStmt); ok {
+		stmt = export.Stmt
+	}
+
+	switch stmt.(type) {
+	case *Function, *ClassStmt:
+		return true
+	}
+	return false
+}
+
+func (p *printer) block(b *Block) {
+	if len(b.Statements) == 0 {
+		p.write("{}")
+		return
+	}
+
+	p.write("{")
+	p.indent++
+	p.newline()
+	p.stmtList(b.Statements)
+	p.indent--
+	p.newline()
+	p.write("}")
+}
+
+func (p *printer) stmt(stmt Stmt) {
+	switch v := stmt.(type) {
+	case *LetStmt:
+		if v.Const {
+			p.write("const ")
+		} else {
+			p.write("let ")
+		}
+		p.write(v.Ident.Name)
+		if v.InitExpr != nil {
+			p.write(" = ")
+			p.expr(v.InitExpr)
+		}
+		p.write(";")
+	case *ExpressionStmt:
+		p.expr(v.Expr)
+		// the match statement does not end with `;`
+		if _, isMatch := v.Expr.(*Match); !isMatch {
+			p.write(";")
+		}
+	case *Function:
+		p.write("fn ")
+		p.function(v)
+	case *ClassStmt:
+		p.class(v)
+	case *Block:
+		p.block(v)
+	case *IFStmt:
+		p.write("if (")
+		p.expr(v.Condition)
+		p.write(") ")
+		p.stmt(v.ThenBranch)
+		if v.ElseBranch != nil {
+			p.write(" else ")
+			p.stmt(v.ElseBranch)
+		}
+	case *WhileStmt:
+		p.write("while (")
+		p.expr(v.Condition)
+		p.write(") ")
+		p.stmt(v.Body)
+	case *ForStmt:
+		p.forStmt(v)
+	case *ForInStmt:
+		p.write("for (")
+		if v.Key != nil {
+			p.write(v.Key.Name, ", ")
+		}
+		p.write(v.Value.Name, " in ")
+		p.expr(v.Iterable)
+		p.write(") ")
+		p.stmt(v.Body)
+	case *ReturnStmt:
+		p.valueStmt("return", v.Value)
+	case *YieldStmt:
+		p.valueStmt("yield", v.Value)
+	case *PrintStmt:
+		p.write("print(")
+		p.exprList(v.Values)
+		p.write(");")
+	case *BreakStmt:
+		p.write("break;")
+	case *ImportStmt:
+		p.importStmt(v)
+	case *ExportStmt:
+		p.write("export ")
+		p.stmt(v.Stmt)
+	case Expression:
+		// Identifier and SliceAccess are statements too
+		p.expr(v)
+		p.write(";")
+	}
+}
+
+func (p *printer) valueStmt(keyword string, value Expression) {
+	p.write(keyword)
+	if value != nil {
+		p.write(" ")
+		p.expr(value)
+	}
+	p.write(";")
+}
+
+// function writes the name, parameters and body of fn, without `fn` which methods do not have
+func (p *printer) function(fn *Function) {
+	p.write(fn.Name.Literal, "(")
+	for idx, param := range fn.Parameters {
+		if idx > 0 {
+			p.write(", ")
+		}
+		p.write(param.Literal)
+		if fn.Defaults != nil && fn.Defaults[idx] != nil {
+			p.write(" = ")
+			p.expr(fn.Defaults[idx])
+		}
+	}
+	if fn.Rest != nil {
+		if len(fn.Parameters) > 0 {
+			p.write(", ")
+		}
+		p.write("...", fn.Rest.Literal)
+	}
+	p.write(") ")
+	p.block(fn.Body)
+}
+
+func (p *printer) class(cls *ClassStmt) {
+	p.write("class ", cls.NameIdent.Name, " ")
+	if len(cls.MethodList) == 0 {
+		p.write("{}")
+		return
+	}
+
+	p.write("{")
+	p.indent++
+	for idx, method := range cls.MethodList {
+		if idx > 0 {
+			p.sb.WriteByte('\n')
+		}
+		p.newline()
+		p.function(method)
+	}
+	p.indent--
+	p.newline()
+	p.write("}")
+}
+
+func (p *printer) forStmt(f *ForStmt) {
+	p.write("for (")
+	if f.Init != nil {
+		p.stmt(f.Init)
+	} else {
+		p.write(";")
+	}
+	if f.Condition != nil {
+		p.write(" ")
+		p.expr(f.Condition)
+	}
+	p.write(";")
+	if f.Increment != nil {
+		p.write(" ")
+		p.expr(f.Increment)
+	}
+	p.write(") ")
+	p.stmt(f.Body)
+}
+
+func (p *printer) importStmt(im *ImportStmt) {
+	if im.Names != nil {
+		p.write("from ", tokens.Quote(im.Path.Literal), " import ")
+		for idx, name := range im.Names {
+			if idx > 0 {
+				p.write(", ")
+			}
+			p.write(name.Literal)
+		}
+		p.write(";")
+		return
+	}
+
+	p.write("import ", tokens.Quote(im.Path.Literal))
+	if im.Alias != nil {
+		p.write(" as ", im.Alias.Literal)
+	}
+	p.write(";")
+}
+
+func (p *printer) exprList(exprs []Expression) {
+	for idx, expr := range exprs {
+		if idx > 0 {
+			p.write(", ")
+		}
+		p.expr(expr)
+	}
+}
+
+func (p *printer) expr(expr Expression) {
+	switch v := expr.(type) {
+	case *Literal:
+		p.literal(v.Value)
+	case *Identifier:
+		p.write(v.Name)
+	case *ThisExpr:
+		p.write("this")
+	case *Grouping:
+		p.write("(")
+		p.expr(v.Expr)
+		p.write(")")
+	case *Assign:
+		p.write(v.Name.Literal, " = ")
+		p.expr(v.Value)
+	case *CompoundAssign:
+		p.expr(v.Target)
+		p.write(" ", v.Operator.Literal, " ")
+		p.expr(v.Value)
+	case *Set:
+		p.expr(v.Expr)
+		p.write(".", v.Name.Literal, " = ")
+		p.expr(v.Value)
+	case *SliceElementAssign:
+		p.expr(v.SLA)
+		p.write(" = ")
+		p.expr(v.Value)
+	case *Binary:
+		p.expr(v.Left)
+		p.write(" ", v.Operator.Literal, " ")
+		p.expr(v.Right)
+	case *Logical:
+		p.expr(v.Left)
+		p.write(" ", v.Operator.Literal, " ")
+		p.expr(v.Right)
+	case *Unary:
+		p.unary(v)
+	case *DExp:
+		if v.Prefix {
+			p.write(v.Operator.Literal)
+			p.expr(v.Left)
+		} else {
+			p.expr(v.Left)
+			p.write(v.Operator.Literal)
+		}
+	case *Ternary:
+		p.expr(v.Condition)
+		p.write(" ? ")
+		p.expr(v.Then)
+		p.write(" : ")
+		p.expr(v.Else)
+	case *Call:
+		p.expr(v.Callee)
+		p.write("(")
+		p.exprList(v.Arguments)
+		p.write(")")
+	case *Spread:
+		p.write("...")
+		p.expr(v.Expr)
+	case *KeywordArg:
+		p.write(v.Name.Literal, ": ")
+		p.expr(v.Value)
+	case *Get:
+		p.expr(v.Expr)
+		if v.Optional {
+			p.write("?.")
+		} else {
+			p.write(".")
+		}
+		p.write(v.Name.Literal)
+	case *OptionalChain:
+		p.expr(v.Expr)
+	case *Slice:
+		p.write("[")
+		p.exprList(v.Elements)
+		p.write("]")
+	case *MapLiteral:
+		p.write("{")
+		for idx, pair := range v.Pairs {
+			if idx > 0 {
+				p.write(", ")
+			}
+			p.mapPair(pair)
+		}
+		p.write("}")
+	case *SliceAccess:
+		p.expr(v.Name)
+		p.write("[")
+		p.expr(v.Idx)
+		p.write("]")
+	case *SliceRange:
+		p.sliceRange(v)
+	case *Match:
+		p.match(v)
+	case *PrintStmt:
+		p.write("print")
+	}
+}
+
+func (p *printer) literal(tk *tokens.Token) {
+	if tk.TkType == tokens.STRING {
+		p.write(tokens.Quote(tk.Literal))
+		return
+	}
+	p.write(tk.Literal)
+}
+
+func (p *printer) unary(u *Unary) {
+	p.write(u.Operator.Literal)
+	// - -x and - --x are not --x and ---x
+	if u.Operator.TkType == tokens.MINUS {
+		switch right := u.Right.(type) {
+		case *Unary:
+			if right.Operator.TkType == tokens.MINUS {
+				p.write(" ")
+			}
+		case *DExp:
+			if right.Prefix {
+				p.write(" ")
+			}
+		}
+	}
+	p.expr(u.Right)
+}
+
+func (p *printer) sliceRange(sr *SliceRange) {
+	p.expr(sr.Name)
+	p.write("[")
+	if sr.Low != nil {
+		p.expr(sr.Low)
+	}
+	p.write(":")
+	if sr.High != nil {
+		p.expr(sr.High)
+	}
+	if sr.Step != nil {
+		p.write(":")
+		p.expr(sr.Step)
+	}
+	p.write("]")
+}
+
+func (p *printer) match(m *Match) {
+	p.write("match (")
+	p.expr(m.Subject)
+	if len(m.Arms) == 0 {
+		p.write(") {}")
+		return
+	}
+
+	p.write(") {")
+	p.indent++
+	for _, arm := range m.Arms {
+		p.newline()
+		p.matchArm(arm)
+	}
+	p.indent--
+	p.newline()
+	p.write("}")
+}
+
+func (p *printer) matchArm(arm *MatchArm) {
+	for idx, pattern := range arm.Patterns {
+		if idx > 0 {
+			p.write(", ")
+		}
+		p.pattern(pattern)
+	}
+	if arm.Guard != nil {
+		p.write(" if ")
+		p.expr(arm.Guard)
+	}
+	p.write(" => ")
+
+	if value, ok := arm.Body.(*ExpressionStmt); ok {
+		p.expr(value.Expr)
+		p.write(",")
+	} else {
+		p.stmt(arm.Body)
+	}
+}
+
+func (p *printer) mapPair(pair *MapPair) {
+	p.expr(pair.Key)
+	p.write(": ")
+	p.expr(pair.Value)
+}
+
+func (p *printer) pattern(mp *MatchPattern) {
+	switch mp.Kind {
+	case PatternBinding:
+		p.write(mp.Name.Literal)
+	case PatternLiteral:
+		p.literal(mp.Value.Value)
+	case PatternRange:
+		p.literal(mp.Value.Value)
+		if mp.Inclusive {
+			p.write("..=")
+		} else {
+			p.write("..")
+		}
+		p.literal(mp.High.Value)
+	}
+}
+
+func (p *Program) String() string              { return Format(p) }
+func (ident *Identifier) String() string       { return Format(ident) }
+func (ls *LetStmt) String() string             { return Format(ls) }
+func (ls *ClassStmt) String() string           { return Format(ls) }
+func (te *ThisExpr) String() string            { return Format(te) }
+func (as *Assign) String() string              { return Format(as) }
+func (ca *CompoundAssign) String() string      { return Format(ca) }
+func (lg *Logical) String() string             { return Format(lg) }
+func (dp *DExp) String() string                { return Format(dp) }
+func (bin *Binary) String() string             { return Format(bin) }
+func (un *Unary) String() string               { return Format(un) }
+func (call *Call) String() string              { return Format(call) }
+func (sp *Spread) String() string              { return Format(sp) }
+func (ka *KeywordArg) String() string          { return Format(ka) }
+func (get *Get) String() string                { return Format(get) }
+func (oc *OptionalChain) String() string       { return Format(oc) }
+func (t *Ternary) String() string              { return Format(t) }
+func (set *Set) String() string                { return Format(set) }
+func (lter *Literal) String() string           { return Format(lter) }
+func (gp *Grouping) String() string            { return Format(gp) }
+func (sl *Slice) String() string               { return Format(sl) }
+func (mp *MapPair) String() string             { return Format(mp) }
+func (ml *MapLiteral) String() string          { return Format(ml) }
+func (sa *SliceAccess) String() string         { return Format(sa) }
+func (sr *SliceRange) String() string          { return Format(sr) }
+func (sea *SliceElementAssign) String() string { return Format(sea) }
+func (mp *MatchPattern) String() string        { return Format(mp) }
+func (arm *MatchArm) String() string           { return Format(arm) }
+func (m *Match) String() string                { return Format(m) }
+func (rt *ReturnStmt) String() string          { return Format(rt) }
+func (ys *YieldStmt) String() string           { return Format(ys) }
+func (blk *Block) String() string              { return Format(blk) }
+func (espst *ExpressionStmt) String() string   { return Format(espst) }
+func (fn *Function) String() string            { return Format(fn) }
+func (ift *IFStmt) String() string             { return Format(ift) }
+func (pt *PrintStmt) String() string           { return Format(pt) }
+func (w *WhileStmt) String() string            { return Format(w) }
+func (f *ForStmt) String() string              { return Format(f) }
+func (f *ForInStmt) String() string            { return Format(f) }
+func (bk *BreakStmt) String() string           { return Format(bk) }
+func (im *ImportStmt) String() string          { return Format(im) }
+func (ex *ExportStmt) String() string          { return Format(ex) }
@@ -0,0 +1,285 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ApplyFunc is called by Apply for each node, with the cursor at the node
+type ApplyFunc func(*Cursor) bool
+
+/*
+Apply walks the tree of root like Walk, and calls pre before the children of each node and post after them,
+like golang.org/x/tools/go/ast/astutil.Apply:
+if pre returns false, the children of the node and post are skipped, if post returns false, Apply stops.
+The functions can replace the current node, or delete and insert the nodes around it if it is in a slice,
+by the methods of Cursor. Apply returns the root, which is the new one if root is replaced.
+
+The derived fields are kept in sync: ClassStmt.Methods with MethodList, and ForStmt.Loop with the clauses.
+*/
+func Apply(root Node, pre, post ApplyFunc) (result Node) {
+	parent := &struct{ Node }{root}
+	defer func() {
+		if r := recover(); r != nil && r != abort {
+			panic(r)
+		}
+		result = parent.Node
+	}()
+
+	a := &application{pre: pre, post: post}
+	a.apply(parent, "Node", nil, root)
+	return
+}
+
+var abort = new(int) // the panic to stop Apply when post returns false
+
+// Cursor is the position of the node which pre or post of Apply is called with
+type Cursor struct {
+	parent Node
+	name   string
+	iter   *iterator // the index of the node if it is an element of a slice
+	node   Node
+}
+
+// Node returns the current node
+func (c *Cursor) Node() Node { return c.node }
+
+// Parent returns the node which has the current node as a field or an element
+func (c *Cursor) Parent() Node { return c.parent }
+
+// Name returns the name of the field of Parent which has the current node, like "Stmts" or "Left"
+func (c *Cursor) Name() string { return c.name }
+
+// Index returns the index of the current node in the slice Parent().Name(), or -1 if it is not in a slice
+func (c *Cursor) Index() int {
+	if c.iter != nil {
+		return c.iter.index
+	}
+	return -1
+}
+
+func (c *Cursor) field() reflect.Value {
+	return reflect.Indirect(reflect.ValueOf(c.parent)).FieldByName(c.name)
+}
+
+// Replace replaces the current node with n, the children of n are walked if it is called by pre
+func (c *Cursor) Replace(n Node) {
+	v := c.field()
+	if i := c.Index(); i >= 0 {
+		v = v.Index(i)
+	}
+	v.Set(nodeValue(n, v.Type()))
+	c.node = n
+}
+
+// Delete deletes the current node from its slice, the following nodes are still walked
+func (c *Cursor) Delete() {
+	i := c.sliceIndex("Delete")
+	v := c.field()
+	l := v.Len()
+	reflect.Copy(v.Slice(i, l), v.Slice(i+1, l))
+	v.Index(l - 1).Set(reflect.Zero(v.Type().Elem()))
+	v.SetLen(l - 1)
+	c.iter.step--
+}
+
+// InsertAfter inserts n after the current node in its slice, n is not walked
+func (c *Cursor) InsertAfter(n Node) {
+	i := c.sliceIndex("InsertAfter")
+	v := c.field()
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+2, l), v.Slice(i+1, l))
+	v.Index(i + 1).Set(nodeValue(n, v.Type().Elem()))
+	c.iter.step++
+}
+
+// InsertBefore inserts n before the current node in its slice, n is not walked
+func (c *Cursor) InsertBefore(n Node) {
+	i := c.sliceIndex("InsertBefore")
+	v := c.field()
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+1, l), v.Slice(i, l))
+	v.Index(i).Set(nodeValue(n, v.Type().Elem()))
+	c.iter.index++
+}
+
+func (c *Cursor) sliceIndex(method string) int {
+	i := c.Index()
+	if i < 0 {
+		panic(fmt.Sprintf("ast.Cursor.%s: %s.%s is not a slice", method, reflect.TypeOf(c.parent), c.name))
+	}
+	return i
+}
+
+func nodeValue(n Node, tp reflect.Type) reflect.Value {
+	if n == nil {
+		return reflect.Zero(tp)
+	}
+	return reflect.ValueOf(n)
+}
+
+// iterator is the index of the current node in a slice, and how far the next node is from it
+type iterator struct {
+	index, step int
+}
+
+type application struct {
+	pre, post ApplyFunc
+	cursor    Cursor
+	iter      iterator
+}
+
+func (a *application) apply(parent Node, name string, iter *iterator, n Node) {
+	// the fields which are nil are skipped like Walk, a typed nil pointer is nil too
+	if v := reflect.ValueOf(n); !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil() {
+		return
+	}
+
+	saved := a.cursor
+	a.cursor = Cursor{parent: parent, name: name, iter: iter, node: n}
+
+	if a.pre != nil && !a.pre(&a.cursor) {
+		a.cursor = saved
+		return
+	}
+
+	switch n := a.cursor.node.(type) {
+	case nil:
+		// deleted or replaced by nil
+	case *Program:
+		a.applyList(n, "Stmts")
+	case *Identifier, *ThisExpr, *Literal, *BreakStmt, *ImportStmt:
+		// no children
+	case *LetStmt:
+		a.apply(n, "Ident", nil, n.Ident)
+		a.apply(n, "InitExpr", nil, n.InitExpr)
+	case *ClassStmt:
+		a.apply(n, "NameIdent", nil, n.NameIdent)
+		a.applyList(n, "MethodList")
+		n.Methods = NewClassStmt(n.NameIdent.Token, n.MethodList).Methods
+	case *Assign:
+		a.apply(n, "Value", nil, n.Value)
+	case *CompoundAssign:
+		a.apply(n, "Target", nil, n.Target)
+		a.apply(n, "Value", nil, n.Value)
+	case *Logical:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+	case *DExp:
+		a.apply(n, "Left", nil, n.Left)
+	case *Binary:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+	case *Unary:
+		a.apply(n, "Right", nil, n.Right)
+	case *Call:
+		a.apply(n, "Callee", nil, n.Callee)
+		a.applyList(n, "Arguments")
+	case *Spread:
+		a.apply(n, "Expr", nil, n.Expr)
+	case *KeywordArg:
+		a.apply(n, "Value", nil, n.Value)
+	case *Get:
+		a.apply(n, "Expr", nil, n.Expr)
+	case *OptionalChain:
+		a.apply(n, "Expr", nil, n.Expr)
+	case *Ternary:
+		a.apply(n, "Condition", nil, n.Condition)
+		a.apply(n, "Then", nil, n.Then)
+		a.apply(n, "Else", nil, n.Else)
+	case *Set:
+		a.apply(n, "Expr", nil, n.Expr)
+		a.apply(n, "Value", nil, n.Value)
+	case *Grouping:
+		a.apply(n, "Expr", nil, n.Expr)
+	case *Slice:
+		a.applyList(n, "Elements")
+	case *MapPair:
+		a.apply(n, "Key", nil, n.Key)
+		a.apply(n, "Value", nil, n.Value)
+	case *MapLiteral:
+		a.applyList(n, "Pairs")
+	case *SliceAccess:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Idx", nil, n.Idx)
+	case *SliceRange:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Low", nil, n.Low)
+		a.apply(n, "High", nil, n.High)
+		a.apply(n, "Step", nil, n.Step)
+	case *SliceElementAssign:
+		a.apply(n, "SLA", nil, n.SLA)
+		a.apply(n, "Value", nil, n.Value)
+	case *MatchPattern:
+		a.apply(n, "Value", nil, n.Value)
+		a.apply(n, "High", nil, n.High)
+	case *MatchArm:
+		a.applyList(n, "Patterns")
+		a.apply(n, "Guard", nil, n.Guard)
+		a.apply(n, "Body", nil, n.Body)
+	case *Match:
+		a.apply(n, "Subject", nil, n.Subject)
+		a.applyList(n, "Arms")
+	case *ReturnStmt:
+		a.apply(n, "Value", nil, n.Value)
+	case *YieldStmt:
+		a.apply(n, "Value", nil, n.Value)
+	case *Block:
+		a.applyList(n, "Statements")
+	case *ExpressionStmt:
+		a.apply(n, "Expr", nil, n.Expr)
+	case *Function:
+		a.applyList(n, "Defaults")
+		a.apply(n, "Body", nil, n.Body)
+	case *IFStmt:
+		a.apply(n, "Condition", nil, n.Condition)
+		a.apply(n, "ThenBranch", nil, n.ThenBranch)
+		a.apply(n, "ElseBranch", nil, n.ElseBranch)
+	case *PrintStmt:
+		a.applyList(n, "Values")
+	case *WhileStmt:
+		a.apply(n, "Condition", nil, n.Condition)
+		a.apply(n, "Body", nil, n.Body)
+	case *ForStmt:
+		a.apply(n, "Init", nil, n.Init)
+		a.apply(n, "Condition", nil, n.Condition)
+		a.apply(n, "Increment", nil, n.Increment)
+		a.apply(n, "Body", nil, n.Body)
+		n.Loop = NewForStmt(n.Init, n.Condition, n.Increment, n.Body).Loop
+	case *ForInStmt:
+		a.apply(n, "Key", nil, n.Key)
+		a.apply(n, "Value", nil, n.Value)
+		a.apply(n, "Iterable", nil, n.Iterable)
+		a.apply(n, "Body", nil, n.Body)
+	case *ExportStmt:
+		a.apply(n, "Stmt", nil, n.Stmt)
+	default:
+		panic(fmt.Sprintf("ast.Apply: unexpected node type %T", n))
+	}
+
+	if a.post != nil && !a.post(&a.cursor) {
+		panic(abort)
+	}
+
+	a.cursor = saved
+}
+
+// applyList applies the elements of the slice parent.name, which can be changed by the cursor while it is walked
+func (a *application) applyList(parent Node, name string) {
+	saved := a.iter
+	a.iter.index = 0
+	for {
+		v := reflect.Indirect(reflect.ValueOf(parent)).FieldByName(name)
+		if a.iter.index >= v.Len() {
+			break
+		}
+
+		x, _ := v.Index(a.iter.index).Interface().(Node)
+		a.iter.step = 1
+		a.apply(parent, name, &a.iter, x)
+		a.iter.index += a.iter.step
+	}
+	a.iter = saved
+}
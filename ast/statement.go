@@ -7,6 +7,9 @@ import (
 type ReturnStmt struct {
 	Keyword *tokens.Token
 	Value   Expression
+	// TailCall is set by the resolver when Value is a call which the function returns directly,
+	// the interpreter runs it after the function returns, so deep tail recursion does not grow the stack
+	TailCall bool
 }
 
 func NewReturnStmt(kw *tokens.Token, value Expression) *ReturnStmt {
@@ -21,6 +24,21 @@ func (rt *ReturnStmt) TokenLiteral() string {
 	return "return"
 }
 
+// YieldStmt is `yield value;` in the body of a generator function
+type YieldStmt struct {
+	Keyword *tokens.Token
+	Value   Expression
+}
+
+func NewYieldStmt(kw *tokens.Token, value Expression) *YieldStmt {
+	return &YieldStmt{Keyword: kw, Value: value}
+}
+
+func (ys *YieldStmt) StmtNode() {}
+func (ys *YieldStmt) TokenLiteral() string {
+	return "yield"
+}
+
 type Block struct {
 	Statements []Stmt
 }
@@ -51,6 +69,11 @@ type Function struct {
 	Name       *tokens.Token
 	Parameters []*tokens.Token
 	Body       *Block
+	Generator  bool // the body contains yield, calling the function returns a generator
+	// Defaults are the default values of Parameters, nil for the parameters without default value,
+	// Defaults is nil if no parameter has a default value
+	Defaults []Expression
+	Rest     *tokens.Token // fn f(a, ...rest), rest is the slice of the extra arguments
 }
 
 func (fn *Function) StmtNode() {}
@@ -105,6 +128,61 @@ func (w *WhileStmt) TokenLiteral() string {
 	return "while"
 }
 
+// ForStmt for (init; cond; increment) body, which runs as Loop:
+// { init; while (cond) { body; increment; } }
+type ForStmt struct {
+	Init      Stmt       // *LetStmt or *ExpressionStmt, nil if it is omitted
+	Condition Expression // nil if it is omitted
+	Increment Expression // nil if it is omitted
+	Body      Stmt
+	Loop      Stmt // the while loop, in a block with init if init is not nil
+}
+
+func NewForStmt(init Stmt, cond, increment Expression, body Stmt) *ForStmt {
+	loopBody := body
+	if increment != nil {
+		loopBody = NewBlockStmt([]Stmt{body, NewExpressionStmt(increment)})
+	}
+
+	loopCond := cond
+	if loopCond == nil {
+		loopCond, _ = NewLiteral1(true)
+	}
+
+	var loop Stmt = NewWhileStmt(loopCond, loopBody)
+	if init != nil {
+		loop = NewBlockStmt([]Stmt{init, loop})
+	}
+
+	return &ForStmt{Init: init, Condition: cond, Increment: increment, Body: body, Loop: loop}
+}
+
+func (f *ForStmt) StmtNode() {}
+func (f *ForStmt) TokenLiteral() string {
+	return "for"
+}
+
+// ForInStmt for (x in xs) body, or for (i, x in xs) body
+type ForInStmt struct {
+	Key      *Identifier // the index of slice and string, or the key of map, nil if it is not declared
+	Value    *Identifier // the element of slice and string, the value of map, or the key of map if Key is nil
+	Iterable Expression
+	Body     Stmt
+}
+
+func NewForInStmt(key, value *tokens.Token, iterable Expression, body Stmt) *ForInStmt {
+	stmt := &ForInStmt{Value: NewIdentifier(value), Iterable: iterable, Body: body}
+	if key != nil {
+		stmt.Key = NewIdentifier(key)
+	}
+	return stmt
+}
+
+func (f *ForInStmt) StmtNode() {}
+func (f *ForInStmt) TokenLiteral() string {
+	return "for"
+}
+
 type BreakStmt struct{}
 
 func NewBreakStmt() *BreakStmt {
@@ -115,3 +193,38 @@ func (bk *BreakStmt) StmtNode() {}
 func (bk *BreakStmt) TokenLiteral() string {
 	return "break"
 }
+
+// import "lib.si" as lib;
+// from "lib.si" import add, sub;
+type ImportStmt struct {
+	Path  *tokens.Token
+	Alias *tokens.Token   // the name bound to the module, nil for `from ... import`
+	Names []*tokens.Token // the names imported by `from ... import`
+}
+
+func NewImportStmt(path, alias *tokens.Token) *ImportStmt {
+	return &ImportStmt{Path: path, Alias: alias}
+}
+
+func NewFromImportStmt(path *tokens.Token, names []*tokens.Token) *ImportStmt {
+	return &ImportStmt{Path: path, Names: names}
+}
+
+func (im *ImportStmt) StmtNode() {}
+func (im *ImportStmt) TokenLiteral() string {
+	return "import"
+}
+
+// export let x = 1;
+type ExportStmt struct {
+	Stmt Stmt // let, fn or class declaration
+}
+
+func NewExportStmt(stmt Stmt) *ExportStmt {
+	return &ExportStmt{Stmt: stmt}
+}
+
+func (ex *ExportStmt) StmtNode() {}
+func (ex *ExportStmt) TokenLiteral() string {
+	return "export"
+}
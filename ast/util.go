@@ -19,6 +19,8 @@ func NewLiteral1(literal any) (*Literal, error) {
 		return NewLiteral(tokens.NewIntegerToken(int64(v))), nil
 	case int32:
 		return NewLiteral(tokens.NewIntegerToken(int64(v))), nil
+	case float64:
+		return NewLiteral(tokens.NewFloatToken(v)), nil
 	case bool:
 		return NewLiteral(tokens.NewBoolToken(v)), nil
 	case string:
@@ -27,3 +29,16 @@ func NewLiteral1(literal any) (*Literal, error) {
 		return nil, fmt.Errorf(ErrUnsuportedLiteralType, reflect.TypeOf(literal))
 	}
 }
+
+// AlwaysReturns reports whether stmt always returns, so the statements after it never run
+func AlwaysReturns(stmt Stmt) bool {
+	switch v := stmt.(type) {
+	case *ReturnStmt:
+		return true
+	case *Block:
+		return len(v.Statements) > 0 && AlwaysReturns(v.Statements[len(v.Statements)-1])
+	case *IFStmt:
+		return v.ElseBranch != nil && AlwaysReturns(v.ThenBranch) && AlwaysReturns(v.ElseBranch)
+	}
+	return false
+}
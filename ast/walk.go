@@ -0,0 +1,187 @@
+package ast
+
+import "fmt"
+
+// Visitor is called by Walk for each node, if it returns a visitor w which is not nil,
+// Walk visits the children of the node with w, and calls w.Visit(nil) after them
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+/*
+Walk visits node and its children in the order of the source, like go/ast.Walk:
+the fields which are nil are skipped, tokens are not nodes and are not visited.
+ForStmt is visited by its clauses and body, and ClassStmt by MethodList, so the nodes shared with
+ForStmt.Loop and ClassStmt.Methods are visited once.
+*/
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	case *Program:
+		walkStmts(v, n.Stmts)
+	case *Identifier, *ThisExpr, *Literal, *BreakStmt, *ImportStmt:
+		// no children
+	case *LetStmt:
+		Walk(v, n.Ident)
+		walkExpr(v, n.InitExpr)
+	case *ClassStmt:
+		Walk(v, n.NameIdent)
+		for _, method := range n.MethodList {
+			Walk(v, method)
+		}
+	case *Assign:
+		walkExpr(v, n.Value)
+	case *CompoundAssign:
+		walkExpr(v, n.Target)
+		walkExpr(v, n.Value)
+	case *Logical:
+		walkExpr(v, n.Left)
+		walkExpr(v, n.Right)
+	case *DExp:
+		walkExpr(v, n.Left)
+	case *Binary:
+		walkExpr(v, n.Left)
+		walkExpr(v, n.Right)
+	case *Unary:
+		walkExpr(v, n.Right)
+	case *Call:
+		walkExpr(v, n.Callee)
+		walkExprs(v, n.Arguments)
+	case *Spread:
+		walkExpr(v, n.Expr)
+	case *KeywordArg:
+		walkExpr(v, n.Value)
+	case *Get:
+		walkExpr(v, n.Expr)
+	case *OptionalChain:
+		walkExpr(v, n.Expr)
+	case *Ternary:
+		walkExpr(v, n.Condition)
+		walkExpr(v, n.Then)
+		walkExpr(v, n.Else)
+	case *Set:
+		walkExpr(v, n.Expr)
+		walkExpr(v, n.Value)
+	case *Grouping:
+		walkExpr(v, n.Expr)
+	case *Slice:
+		walkExprs(v, n.Elements)
+	case *MapPair:
+		walkExpr(v, n.Key)
+		walkExpr(v, n.Value)
+	case *MapLiteral:
+		for _, pair := range n.Pairs {
+			Walk(v, pair)
+		}
+	case *SliceAccess:
+		walkExpr(v, n.Name)
+		walkExpr(v, n.Idx)
+	case *SliceRange:
+		walkExpr(v, n.Name)
+		walkExpr(v, n.Low)
+		walkExpr(v, n.High)
+		walkExpr(v, n.Step)
+	case *SliceElementAssign:
+		Walk(v, n.SLA)
+		walkExpr(v, n.Value)
+	case *MatchPattern:
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+		if n.High != nil {
+			Walk(v, n.High)
+		}
+	case *MatchArm:
+		for _, pattern := range n.Patterns {
+			Walk(v, pattern)
+		}
+		walkExpr(v, n.Guard)
+		walkStmt(v, n.Body)
+	case *Match:
+		walkExpr(v, n.Subject)
+		for _, arm := range n.Arms {
+			Walk(v, arm)
+		}
+	case *ReturnStmt:
+		walkExpr(v, n.Value)
+	case *YieldStmt:
+		walkExpr(v, n.Value)
+	case *Block:
+		walkStmts(v, n.Statements)
+	case *ExpressionStmt:
+		walkExpr(v, n.Expr)
+	case *Function:
+		walkExprs(v, n.Defaults)
+		Walk(v, n.Body)
+	case *IFStmt:
+		walkExpr(v, n.Condition)
+		walkStmt(v, n.ThenBranch)
+		walkStmt(v, n.ElseBranch)
+	case *PrintStmt:
+		walkExprs(v, n.Values)
+	case *WhileStmt:
+		walkExpr(v, n.Condition)
+		walkStmt(v, n.Body)
+	case *ForStmt:
+		walkStmt(v, n.Init)
+		walkExpr(v, n.Condition)
+		walkExpr(v, n.Increment)
+		walkStmt(v, n.Body)
+	case *ForInStmt:
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		Walk(v, n.Value)
+		walkExpr(v, n.Iterable)
+		walkStmt(v, n.Body)
+	case *ExportStmt:
+		walkStmt(v, n.Stmt)
+	default:
+		panic(fmt.Sprintf("ast.Walk: unexpected node type %T", n))
+	}
+
+	v.Visit(nil)
+}
+
+func walkExpr(v Visitor, expr Expression) {
+	if expr != nil {
+		Walk(v, expr)
+	}
+}
+
+// walkExprs skips the nil elements, like the parameters without default in Function.Defaults
+func walkExprs(v Visitor, exprs []Expression) {
+	for _, expr := range exprs {
+		walkExpr(v, expr)
+	}
+}
+
+func walkStmt(v Visitor, stmt Stmt) {
+	if stmt != nil {
+		Walk(v, stmt)
+	}
+}
+
+func walkStmts(v Visitor, stmts []Stmt) {
+	for _, stmt := range stmts {
+		walkStmt(v, stmt)
+	}
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect walks the tree of node, it calls f(node) for each node, and f(nil) after the children of the node.
+// the children are skipped if f returns false
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
@@ -0,0 +1,234 @@
+package ast_test
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+// allNodes has every type of node
+const allNodes = `
+import "lib.si" as lib;
+export let x = 1 + 2 * -y;
+const c = a && b || !a;
+class P { add(v = 1, ...rest) { this.v += v; this.w = v; return this.v; } }
+fn gen() { yield p?.v; print(x, this); }
+x = f(...xs, k: 1) ? (2) : x++;
+xs[0] = xs[1:2:3][0];
+let m = {"a": [1]};
+if (x) { while (x) { break; } } else for (let i = 0; i < 3; i++) {}
+for (k, v in m) { match (v) { 1, 2..5 if v > 1 => v, n => { return n; } } }
+`
+
+func nodeName(node ast.Node) string {
+	return reflect.TypeOf(node).Elem().Name()
+}
+
+func TestInspectVisitsEveryNode(t *testing.T) {
+	program := parse(t, allNodes)
+
+	visited := map[string]bool{}
+	ast.Inspect(program, func(node ast.Node) bool {
+		if node != nil {
+			visited[nodeName(node)] = true
+		}
+		return true
+	})
+
+	var names []string
+	for name := range visited {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	assert.Equal(t, []string{
+		"Assign", "Binary", "Block", "BreakStmt", "Call", "ClassStmt", "CompoundAssign", "DExp", "ExportStmt",
+		"ExpressionStmt", "ForInStmt", "ForStmt", "Function", "Get", "Grouping", "IFStmt", "Identifier",
+		"ImportStmt", "KeywordArg", "LetStmt", "Literal", "Logical", "MapLiteral", "MapPair", "Match",
+		"MatchArm", "MatchPattern", "OptionalChain", "PrintStmt", "Program", "ReturnStmt", "Set", "Slice",
+		"SliceAccess", "SliceElementAssign", "SliceRange", "Spread", "Ternary", "ThisExpr", "Unary",
+		"WhileStmt", "YieldStmt",
+	}, names)
+}
+
+type recorder struct {
+	events *[]string
+}
+
+func (r recorder) Visit(node ast.Node) ast.Visitor {
+	if node == nil {
+		*r.events = append(*r.events, "end")
+		return nil
+	}
+	*r.events = append(*r.events, nodeName(node))
+	return r
+}
+
+func TestWalkOrder(t *testing.T) {
+	program := parse(t, `for (let i = 0; i < n; i++) print(i);`)
+
+	var events []string
+	ast.Walk(recorder{events: &events}, program)
+	assert.Equal(t, strings.Join([]string{
+		"Program",
+		"ForStmt",
+		"LetStmt", "Identifier", "end", "Literal", "end", "end",
+		"Binary", "Identifier", "end", "Identifier", "end", "end",
+		"DExp", "Identifier", "end", "end",
+		"PrintStmt", "Identifier", "end", "end",
+		"end",
+		"end",
+	}, " "), strings.Join(events, " "))
+}
+
+func TestInspectSkipsChildren(t *testing.T) {
+	program := parse(t, `fn f(a) { return g(a); } h(1);`)
+
+	var calls []string
+	ast.Inspect(program, func(node ast.Node) bool {
+		if call, ok := node.(*ast.Call); ok {
+			calls = append(calls, call.Callee.TokenLiteral())
+		}
+		_, isFunction := node.(*ast.Function)
+		return !isFunction
+	})
+	assert.Equal(t, []string{"h"}, calls)
+}
+
+// foldAdd replaces the sum of two integer literals with the result
+func foldAdd(c *ast.Cursor) bool {
+	bin, ok := c.Node().(*ast.Binary)
+	if !ok || bin.Operator.TkType != tokens.PLUS {
+		return true
+	}
+
+	left, lok := bin.Left.(*ast.Literal)
+	right, rok := bin.Right.(*ast.Literal)
+	if lok && rok && left.Value.TkType == tokens.INTEGER && right.Value.TkType == tokens.INTEGER {
+		sum := left.Value.Value.(int64) + right.Value.Value.(int64)
+		c.Replace(ast.NewLiteral(tokens.NewIntegerToken(sum)))
+	}
+	return true
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		pre   ast.ApplyFunc
+		post  ast.ApplyFunc
+		want  string
+	}{
+		{
+			name:  "replace in post folds the children first",
+			input: `let x = 1 + 2 + 3; fn f(a = 4 + 5) { return a + 1; }`,
+			post:  foldAdd,
+			want:  "let x = 6;\n\nfn f(a = 9) {\n\treturn a + 1;\n}\n",
+		},
+		{
+			name:  "delete statements",
+			input: `print(1); let x = 2; print(3); { print(4); x = 5; }`,
+			pre: func(c *ast.Cursor) bool {
+				if _, ok := c.Node().(*ast.PrintStmt); ok {
+					c.Delete()
+				}
+				return true
+			},
+			want: "let x = 2;\n{\n\tx = 5;\n}\n",
+		},
+		{
+			name:  "insert around statements",
+			input: `x = 1; y = 2;`,
+			pre: func(c *ast.Cursor) bool {
+				if stmt, ok := c.Node().(*ast.ExpressionStmt); ok && c.Name() == "Stmts" {
+					name := stmt.Expr.(*ast.Assign).Name.Literal
+					c.InsertBefore(ast.NewPrintStmt([]ast.Expression{ast.NewLiteral(tokens.NewStringToken("before " + name))}))
+					c.InsertAfter(ast.NewPrintStmt([]ast.Expression{ast.NewLiteral(tokens.NewStringToken("after " + name))}))
+				}
+				return true
+			},
+			want: "print(\"before x\");\nx = 1;\nprint(\"after x\");\nprint(\"before y\");\ny = 2;\nprint(\"after y\");\n",
+		},
+		{
+			name:  "skip children",
+			input: `let x = 1 + 2; fn f() { return 1 + 2; }`,
+			pre: func(c *ast.Cursor) bool {
+				_, isFunction := c.Node().(*ast.Function)
+				return !isFunction
+			},
+			post: foldAdd,
+			want: "let x = 3;\n\nfn f() {\n\treturn 1 + 2;\n}\n",
+		},
+		{
+			name:  "stop",
+			input: `let x = 1 + 2; let y = 3 + 4;`,
+			post: func(c *ast.Cursor) bool {
+				foldAdd(c)
+				_, isLet := c.Node().(*ast.LetStmt)
+				return !isLet
+			},
+			want: "let x = 3;\nlet y = 3 + 4;\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := parse(t, tt.input)
+			result := ast.Apply(program, tt.pre, tt.post)
+			assert.Equal(t, tt.want, ast.Format(result))
+		})
+	}
+}
+
+func TestApplyCursor(t *testing.T) {
+	program := parse(t, `f(a, b);`)
+
+	var positions []string
+	ast.Apply(program, func(c *ast.Cursor) bool {
+		positions = append(positions, fmt.Sprintf("%s.%s[%d] %s", nodeName(c.Parent()), c.Name(), c.Index(), nodeName(c.Node())))
+		return true
+	}, nil)
+
+	assert.Equal(t, []string{
+		".Node[-1] Program",
+		"Program.Stmts[0] ExpressionStmt",
+		"ExpressionStmt.Expr[-1] Call",
+		"Call.Callee[-1] Identifier",
+		"Call.Arguments[0] Identifier",
+		"Call.Arguments[1] Identifier",
+	}, positions)
+}
+
+func TestApplyKeepsDerivedFields(t *testing.T) {
+	program := parse(t, `class P { a() { return 1 + 1; } b() {} } for (let i = 0; i < 1 + 2; i++) { print(1 + 3); }`)
+
+	ast.Apply(program, func(c *ast.Cursor) bool {
+		if fn, ok := c.Node().(*ast.Function); ok && fn.Name.Literal == "b" {
+			c.Delete()
+		}
+		return true
+	}, foldAdd)
+
+	want := parse(t, `class P { a() { return 2; } } for (let i = 0; i < 3; i++) { print(4); }`)
+	assert.Equal(t, want, program)
+}
+
+func TestApplyReplaceRoot(t *testing.T) {
+	program := parse(t, `1;`)
+	replaced := ast.NewLiteral(tokens.NewIntegerToken(2))
+
+	result := ast.Apply(program, func(c *ast.Cursor) bool {
+		if _, ok := c.Node().(*ast.Program); ok {
+			c.Replace(replaced)
+		}
+		return true
+	}, nil)
+	assert.Equal(t, replaced, result)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/eval"
+	"github.com/forfd8960/simpleinterpreter/lexer"
+	"github.com/forfd8960/simpleinterpreter/optimize"
+	"github.com/forfd8960/simpleinterpreter/parser"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+// runTokens prints the tokens of the script: tokens file, one token in each line with its position, type and literal
+func runTokens(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: simpleinterpreter tokens file")
+		return 2
+	}
+
+	src, err := os.ReadFile(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	defer w.Flush()
+
+	l := lexer.NewLexer(string(src))
+	for {
+		tk, err := l.NextToken()
+		if err != nil {
+			w.Flush()
+			fmt.Fprintf(os.Stderr, "%s:%s: %v\n", args[0], l.Position(), err)
+			return 1
+		}
+
+		literal := tk.Literal
+		if tk.TkType == tokens.STRING {
+			literal = tokens.Quote(literal)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", l.Position(), tk.TkType, literal)
+
+		if tk.TkType == tokens.EOF {
+			return 0
+		}
+	}
+}
+
+// runAST prints the syntax tree of the script: ast [--json] [--optimize] file, as an indented tree or as json
+func runAST(args []string) int {
+	flags := flag.NewFlagSet("ast", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the tree as json")
+	optimized := flags.Bool("optimize", false, "print the tree rewritten by the optimizer")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: simpleinterpreter ast [--json] [--optimize] file")
+		return 2
+	}
+
+	file := flags.Arg(0)
+	src, err := os.ReadFile(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	tokenList, err := lexer.TokensFromInput(string(src))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", file, err)
+		return 1
+	}
+
+	program, err := parser.NewParser(tokenList).ParseProgram()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", file, err)
+		return 1
+	}
+
+	if *optimized {
+		program = optimize.Program(program, &eval.Options{StrictBool: *strictBool})
+	}
+
+	if !*asJSON {
+		fmt.Print(ast.Dump(program))
+		return 0
+	}
+
+	data, err := ast.EncodeJSON(program)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	os.Stdout.Write(data)
+	return 0
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/forfd8960/simpleinterpreter/format"
+)
+
+// runFmt formats the scripts: fmt [-w] [-l] [file...], it formats stdin if there is no file
+func runFmt(args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	write := flags.Bool("w", false, "write the result to the file instead of stdout")
+	list := flags.Bool("l", false, "list the files whose formatting differs from the canonical one")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		src, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		out, err := format.Source(src)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "<stdin>: %v\n", err)
+			return 1
+		}
+		os.Stdout.Write(out)
+		return 0
+	}
+
+	status := 0
+	for _, file := range flags.Args() {
+		if err := formatFile(file, *write, *list); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", file, err)
+			status = 1
+		}
+	}
+	return status
+}
+
+func formatFile(file string, write, list bool) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	out, err := format.Source(src)
+	if err != nil {
+		return err
+	}
+
+	changed := !bytes.Equal(src, out)
+	if list && changed {
+		fmt.Println(file)
+	}
+
+	switch {
+	case write:
+		if changed {
+			return os.WriteFile(file, out, info.Mode().Perm())
+		}
+	case !list:
+		_, err = os.Stdout.Write(out)
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/forfd8960/simpleinterpreter/lint"
+)
+
+// runLint checks the scripts: lint [-json] [-severity rule=level,...] file...,
+// it exits with 1 if there is a diagnostic whose severity is error
+func runLint(args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the diagnostics as a json array")
+	severities := flags.String("severity", "", "comma separated severities of the rules, like unused-variable=off,shadowed-name=error")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: simpleinterpreter lint [-json] [-severity rule=level,...] file...")
+		return 2
+	}
+
+	cfg := &lint.Config{}
+	var err error
+	if cfg.Severities, err = lint.ParseSeverities(*severities); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	status := 0
+	diagnostics := []lint.Diagnostic{}
+	for _, file := range flags.Args() {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			status = 1
+			continue
+		}
+
+		for _, d := range lint.Source(file, src, cfg) {
+			if d.Severity == lint.SeverityError {
+				status = 1
+			}
+			diagnostics = append(diagnostics, d)
+		}
+	}
+
+	if !*asJSON {
+		for _, d := range diagnostics {
+			fmt.Println(d)
+		}
+		return status
+	}
+
+	data, err := json.MarshalIndent(diagnostics, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Println(string(data))
+	return status
+}
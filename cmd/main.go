@@ -1,20 +1,83 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/forfd8960/simpleinterpreter/eval"
+	"github.com/forfd8960/simpleinterpreter/optimize"
 	"github.com/forfd8960/simpleinterpreter/repl"
 )
 
+var (
+	fsRoots    = flag.String("fs-root", "", "comma separated directories which the script can access by the fs module")
+	fsReadOnly = flag.Bool("fs-readonly", false, "deny the script writing files")
+	envVars    = flag.String("env", "", "comma separated env variables which the script can read by os.env")
+	strictBool = flag.Bool("strict-bool", false, "require conditions and the operands of !, && and || to be bool values")
+	optimized  = flag.Bool("optimize", false, "fold the constant expressions and prune the dead code before running the script")
+	overflow   eval.OverflowMode
+)
+
+func init() {
+	flag.Var(&overflow, "int-overflow", "what integer arithmetic does when it overflows int64: error or bigint")
+}
+
 func main() {
-	args := os.Args
-	if len(args) == 1 {
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) > 0 {
+		switch args[0] {
+		case "fmt":
+			os.Exit(runFmt(args[1:]))
+		case "tokens":
+			os.Exit(runTokens(args[1:]))
+		case "ast":
+			os.Exit(runAST(args[1:]))
+		case "lint":
+			os.Exit(runLint(args[1:]))
+		}
+	}
+
+	if len(args) == 0 {
 		fmt.Println("-------starting simple interpreter-------")
 		fmt.Println("feel free to type expressions")
 		repl.Start(os.Stdin, os.Stdout)
-	} else if len(args) > 1 {
-		file := os.Args[1]
-		repl.RunScript(file)
+		return
+	}
+
+	opts := &eval.Options{
+		Capabilities: eval.Capabilities{
+			FSRoots:      splitList(*fsRoots),
+			ReadOnly:     *fsReadOnly,
+			ImportRoots:  []string{filepath.Dir(args[0])},
+			EnvAllowlist: splitList(*envVars),
+			Args:         args[1:],
+			Exit:         true,
+		},
+		StrictBool:  *strictBool,
+		IntOverflow: overflow,
+	}
+	if *optimized {
+		opts.Optimizer = optimize.Program
+	}
+
+	if err := repl.RunScript(args[0], opts); err != nil {
+		var exit *eval.ExitError
+		if errors.As(err, &exit) {
+			os.Exit(exit.Code)
+		}
+		os.Exit(1)
+	}
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
 	}
+	return strings.Split(s, ",")
 }
@@ -0,0 +1,155 @@
+package eval
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+var (
+	ErrUnknownKeywordArg  = "%s got an unexpected keyword argument: %s"
+	ErrDuplicateArgument  = "%s got multiple values for argument: %s"
+	ErrMissingArguments   = "%s missing arguments: %s"
+	ErrNoKeywordArguments = "%s does not accept keyword arguments"
+)
+
+// keywordArg is an evaluated `name: value` argument
+type keywordArg struct {
+	name  string
+	value object.Object
+}
+
+// evalArguments evaluates the arguments of a call,
+// the elements of ...expr are appended to the positional arguments, and name: value are returned as keyword arguments
+func evalArguments(exprs []ast.Expression, env *object.Environment) ([]object.Object, []keywordArg, error) {
+	args := make([]object.Object, 0, len(exprs))
+	var kwargs []keywordArg
+	for _, e := range exprs {
+		switch arg := e.(type) {
+		case *ast.Spread:
+			v, err := Eval(arg.Expr, env)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			elements, err := iterableArg("spread", v)
+			if err != nil {
+				return nil, nil, err
+			}
+			args = append(args, elements...)
+		case *ast.KeywordArg:
+			v, err := Eval(arg.Value, env)
+			if err != nil {
+				return nil, nil, err
+			}
+			kwargs = append(kwargs, keywordArg{name: arg.Name.Literal, value: v})
+		default:
+			v, err := Eval(e, env)
+			if err != nil {
+				return nil, nil, err
+			}
+			args = append(args, v)
+		}
+	}
+
+	return args, kwargs, nil
+}
+
+// bindArguments creates the env of a call to fn: the parameters are bound to the positional arguments in order,
+// then to the keyword arguments by name, and the others get their default values,
+// which are evaluated in the env so they can refer to the parameters before them
+func bindArguments(fn *object.Function, args []object.Object, kwargs []keywordArg) (*object.Environment, error) {
+	params := fn.Parameters
+	if len(args) > len(params) && fn.Rest == nil {
+		return nil, arityError(fn, len(args))
+	}
+
+	env := object.NewEnvWithOutter(fn.Env)
+	bound := make([]bool, len(params))
+	for idx := 0; idx < len(args) && idx < len(params); idx++ {
+		env.Set(params[idx].Name, args[idx])
+		bound[idx] = true
+	}
+
+	if fn.Rest != nil {
+		extra := []object.Object{}
+		if len(args) > len(params) {
+			extra = append(extra, args[len(params):]...)
+		}
+		env.Set(fn.Rest.Name, &object.Slice{Elements: extra})
+	}
+
+	for _, kw := range kwargs {
+		idx := paramIndex(params, kw.name)
+		if idx < 0 {
+			return nil, fmt.Errorf(ErrUnknownKeywordArg, functionName(fn), kw.name)
+		}
+		if bound[idx] {
+			return nil, fmt.Errorf(ErrDuplicateArgument, functionName(fn), kw.name)
+		}
+
+		env.Set(kw.name, kw.value)
+		bound[idx] = true
+	}
+
+	var missing []string
+	for idx, param := range params {
+		if bound[idx] {
+			continue
+		}
+
+		if fn.Defaults == nil || fn.Defaults[idx] == nil {
+			missing = append(missing, param.Name)
+			continue
+		}
+
+		value, err := Eval(fn.Defaults[idx], env)
+		if err != nil {
+			return nil, err
+		}
+		env.Set(param.Name, value)
+	}
+
+	if len(missing) > 0 {
+		if len(kwargs) == 0 {
+			return nil, arityError(fn, len(args))
+		}
+		return nil, fmt.Errorf(ErrMissingArguments, functionName(fn), strings.Join(missing, ", "))
+	}
+	return env, nil
+}
+
+// arityError reports the wrong number of positional arguments, like the builtins do
+func arityError(fn *object.Function, got int) error {
+	required := 0
+	for idx := range fn.Parameters {
+		if fn.Defaults == nil || fn.Defaults[idx] == nil {
+			required++
+		}
+	}
+
+	max := len(fn.Parameters)
+	if fn.Rest != nil {
+		max = -1
+	}
+	return fmt.Errorf(ErrWrongNumberOfArguments, functionName(fn), got, wantArgs(required, max))
+}
+
+func paramIndex(params []*ast.Identifier, name string) int {
+	for idx, param := range params {
+		if param.Name == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+// functionName is the name of fn in the error messages
+func functionName(fn *object.Function) string {
+	if fn.Name != "" {
+		return fn.Name
+	}
+	return fn.Inspect()
+}
@@ -0,0 +1,137 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func TestEvalArguments(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "default values",
+			input: `
+			fn f(a, b = 10, c = a + b) { return [a, b, c]; }
+			return [f(1), f(1, 2), f(1, 2, 3)];
+			`,
+			want: &object.Slice{Elements: []object.Object{intSlice(1, 10, 11), intSlice(1, 2, 3), intSlice(1, 2, 3)}},
+		},
+		{
+			name: "default value is evaluated in each call",
+			input: `
+			fn f(xs = []) { xs.push(1); return xs; }
+			f();
+			return f();
+			`,
+			want: intSlice(1),
+		},
+		{
+			name: "rest parameter",
+			input: `
+			fn f(first, ...rest) { return [first, rest]; }
+			return [f(1), f(1, 2, 3)];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.Slice{Elements: []object.Object{&object.Integer{Value: 1}, intSlice()}},
+				&object.Slice{Elements: []object.Object{&object.Integer{Value: 1}, intSlice(2, 3)}},
+			}},
+		},
+		{
+			name: "spread",
+			input: `
+			fn f(a, b, c) { return a * 100 + b * 10 + c; }
+			let xs = [2, 3];
+			return [f(1, ...xs), f(...[1, 2, 3]), f(...xs, 4)];
+			`,
+			want: intSlice(123, 123, 234),
+		},
+		{
+			name: "spread iterable into rest and builtin",
+			input: `
+			fn f(...rest) { return rest; }
+			return [f(..."ab"), append([], ...{"a": 1, "b": 2}.values())];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.Slice{Elements: []object.Object{&object.String{Value: "a"}, &object.String{Value: "b"}}},
+				intSlice(1, 2),
+			}},
+		},
+		{
+			name: "keyword arguments",
+			input: `
+			fn f(a, b = 2, c = 3) { return [a, b, c]; }
+			return [f(1, c: 30), f(b: 20, a: 10), f(c: 3, a: 1, b: 2)];
+			`,
+			want: &object.Slice{Elements: []object.Object{intSlice(1, 2, 30), intSlice(10, 20, 3), intSlice(1, 2, 3)}},
+		},
+		{
+			name: "keyword arguments of method",
+			input: `
+			class Greeter {
+				greet(name, greeting = "hello") { return greeting + " " + name; }
+			}
+			return Greeter().greet(greeting: "hi", name: "bob");
+			`,
+			want: &object.String{Value: "hi bob"},
+		},
+		{
+			name:  "signature",
+			input: `fn f(a, b = 1, c = "x", d = a + 1, ...rest) {} return str(f);`,
+			want:  &object.String{Value: `fn f(a, b = 1, c = "x", d = a + 1, ...rest)`},
+		},
+		{
+			name:  "too many arguments",
+			input: `fn add(a, b) { return a + b; } add(1, 2, 3);`,
+			want:  &object.Error{Message: "wrong number of arguments to add: got 3, want 2"},
+		},
+		{
+			name:  "too few arguments",
+			input: `fn f(a, b = 1) { return a; } f();`,
+			want:  &object.Error{Message: "wrong number of arguments to f: got 0, want 1 to 2"},
+		},
+		{
+			name:  "too few arguments with rest",
+			input: `fn f(a, ...rest) { return a; } f();`,
+			want:  &object.Error{Message: "wrong number of arguments to f: got 0, want at least 1"},
+		},
+		{
+			name:  "missing keyword arguments",
+			input: `fn f(a, b, c) { return a; } f(1, c: 2);`,
+			want:  &object.Error{Message: "f missing arguments: b"},
+		},
+		{
+			name:  "unknown keyword argument",
+			input: `fn f(a) { return a; } f(1, b: 2);`,
+			want:  &object.Error{Message: "f got an unexpected keyword argument: b"},
+		},
+		{
+			name:  "argument given twice",
+			input: `fn f(a) { return a; } f(1, a: 2);`,
+			want:  &object.Error{Message: "f got multiple values for argument: a"},
+		},
+		{
+			name:  "builtin keyword argument",
+			input: `len(x: "a");`,
+			want:  &object.Error{Message: "len does not accept keyword arguments"},
+		},
+		{
+			name:  "spread not iterable",
+			input: `fn f(...rest) { return rest; } f(...1);`,
+			want:  &object.Error{Message: "spread: 1 is not iterable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
@@ -10,6 +10,9 @@ import (
 const (
 	builtInPrint  = "print"
 	builtInAppend = "append"
+	builtInFreeze = "freeze"
+	builtInStr    = "str"
+	builtInRepr   = "repr"
 	argFormat     = "format"
 )
 
@@ -17,83 +20,148 @@ var (
 	ErrLackParameter              = fmt.Errorf("lack parameters for print")
 	ErrUnsupportedBuildInFunction = fmt.Errorf("unsupported buildin function")
 	ErrInvalidParameterType       = fmt.Errorf("invalid parameters for print(first paramter msut be string)")
+	ErrWrongNumberOfArguments     = "wrong number of arguments to %s: got %d, want %s"
 )
 
-var builtInfunctions = map[string]struct{}{
-	builtInPrint:  {},
-	builtInAppend: {},
+var (
+	builtins       map[string]*object.Builtin
+	builtinModules map[string]*object.Module
+)
+
+func init() {
+	builtins = make(map[string]*object.Builtin)
+	registerBuiltin(builtInAppend, builtinAppend)
+	registerBuiltin(builtInFreeze, builtinFreeze)
+	registerBuiltin(builtInStr, builtinStr)
+	registerBuiltin(builtInRepr, builtinRepr)
+	registerSliceBuiltins()
+	registerIteratorBuiltins()
+
+	builtinModules = map[string]*object.Module{
+		moduleJSON: newJSONModule(),
+	}
+}
+
+func registerBuiltin(name string, fn object.BuiltinFunction) {
+	builtins[name] = &object.Builtin{Name: name, Fn: fn}
 }
 
 func IsBuiltInFunction(fnName string) bool {
-	_, ok := builtInfunctions[fnName]
+	_, ok := builtins[fnName]
 	return ok
 }
 
-func buildFunctionFromCall(callExpr *ast.Call, env *object.Environment) *object.Function {
-	identifiers := make([]*ast.Identifier, 0, len(callExpr.Arguments))
-	identifiers = append(identifiers, ast.NewIdentifier1(argFormat))
+// lookupBuiltin finds the builtin function or module by name
+func lookupBuiltin(name string, env *object.Environment) (object.Object, bool) {
+	if b, ok := builtins[name]; ok {
+		return b, true
+	}
 
-	for i := 1; i < len(callExpr.Arguments); i++ {
-		identifiers = append(identifiers, ast.NewIdentifier1(fmt.Sprintf("val%d", i)))
+	if m, ok := builtinModules[name]; ok {
+		return m, true
 	}
 
-	return &object.Function{
-		Parameters: identifiers,
+	if m, ok := interpreterOf(env).modules[name]; ok {
+		return m, true
 	}
+	return nil, false
 }
 
-var buildPrint = func(callExpr *ast.Call, env *object.Environment) *object.Function {
-	identifiers := make([]*ast.Identifier, 0, len(callExpr.Arguments))
-	identifiers = append(identifiers, ast.NewIdentifier1(argFormat))
+func checkArgsCount(name string, args []object.Object, min, max int) error {
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		return fmt.Errorf(ErrWrongNumberOfArguments, name, len(args), wantArgs(min, max))
+	}
 
-	for i := 1; i < len(callExpr.Arguments); i++ {
-		identifiers = append(identifiers, ast.NewIdentifier1(fmt.Sprintf("val%d", i)))
+	return nil
+}
+
+// wantArgs describes the number of arguments between min and max, max < 0 means no limit
+func wantArgs(min, max int) string {
+	switch {
+	case max < 0:
+		return fmt.Sprintf("at least %d", min)
+	case max != min:
+		return fmt.Sprintf("%d to %d", min, max)
 	}
+	return fmt.Sprintf("%d", min)
+}
 
-	return &object.Function{
-		Parameters: identifiers,
+func builtinAppend(args ...object.Object) (object.Object, error) {
+	if len(args) < 1 {
+		return nil, ErrLackParameter
+	}
+
+	slice, ok := args[0].(*object.Slice)
+	if !ok {
+		return nil, fmt.Errorf("%v must be a slice", args[0].Inspect())
+	}
+
+	if err := slice.Append(args[1:]...); err != nil {
+		return nil, err
 	}
+	return slice, nil
 }
 
-func evalBuildInFunctions(name string, callExpr *ast.Call, globalEnv *object.Environment) (object.Object, error) {
-	switch name {
-	case builtInAppend:
-		return evalBuildtInAppend(callExpr, globalEnv)
-	default:
-		return nil, ErrUnsupportedBuildInFunction
+// freeze(obj) makes obj and the slices, maps and instances in it read-only, and returns obj
+func builtinFreeze(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInFreeze, args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	object.Freeze(args[0])
+	return args[0], nil
+}
+
+// str(v) returns the string to print for v, it calls v.__str__() if the class of v defines it
+func builtinStr(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInStr, args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	s, err := strOf(args[0])
+	if err != nil {
+		return nil, err
 	}
+	return &object.String{Value: s}, nil
 }
 
-func evalBuildtInAppend(callExpr *ast.Call, globalEnv *object.Environment) (object.Object, error) {
-	if len(callExpr.Arguments) < 1 {
-		return nil, ErrLackParameter
+// repr(v) returns the representation of v, which reads back as the same value: repr("a") is "\"a\""
+func builtinRepr(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInRepr, args, 1, 1); err != nil {
+		return nil, err
 	}
+	return &object.String{Value: object.Repr(args[0])}, nil
+}
 
-	var values []object.Object
+func strOf(obj object.Object) (string, error) {
+	method, ok := specialMethod(obj, methodStr)
+	if !ok {
+		return object.Str(obj), nil
+	}
 
-	var slice *object.Slice
-	var ok bool
+	s, err := applyFunction(method, nil)
+	if err != nil {
+		return "", err
+	}
 
-	fn := buildFunctionFromCall(callExpr, globalEnv)
-	for idx := range fn.Parameters {
-		v, err := Eval(callExpr.Arguments[idx], globalEnv)
-		if err != nil {
-			return nil, err
-		}
+	str, isStr := s.(*object.String)
+	if !isStr {
+		return "", fmt.Errorf("%s must return a string, got: %s", methodStr, inspect(s))
+	}
+	return str.Value, nil
+}
 
-		if idx == 0 {
-			slice, ok = v.(*object.Slice)
-			if !ok {
-				return nil, fmt.Errorf("%v must be a slice", v.Inspect())
-			}
-			continue
-		}
+var buildPrint = func(callExpr *ast.Call, env *object.Environment) *object.Function {
+	identifiers := make([]*ast.Identifier, 0, len(callExpr.Arguments))
+	identifiers = append(identifiers, ast.NewIdentifier1(argFormat))
 
-		values = append(values, v)
+	for i := 1; i < len(callExpr.Arguments); i++ {
+		identifiers = append(identifiers, ast.NewIdentifier1(fmt.Sprintf("val%d", i)))
 	}
 
-	slice.Append(values...)
-	return slice, nil
+	return &object.Function{
+		Parameters: identifiers,
+	}
 }
 
 func evalBuiltInPrint(callExpr *ast.Call, globalEnv *object.Environment) (object.Object, error) {
@@ -121,24 +189,32 @@ func evalBuiltInPrint(callExpr *ast.Call, globalEnv *object.Environment) (object
 			continue
 		}
 
-		values = append(values, getValueLiteral(v))
+		value, err := getValueLiteral(v)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
 	}
 
 	_, err := fmt.Printf(format, values...)
 	return nil, err
 }
 
-func getValueLiteral(v object.Object) any {
+// getValueLiteral returns the go value of v for the format of print,
+// the values which have no go counterpart are printed as str(v)
+func getValueLiteral(v object.Object) (any, error) {
 	switch data := v.(type) {
 	case *object.Integer:
-		return data.Value
+		return data.Value, nil
+	case *object.BigInt:
+		return data.Value, nil
+	case *object.Float:
+		return data.Value, nil
 	case *object.String:
-		return data.Value
+		return data.Value, nil
 	case *object.Bool:
-		return data.Value
-	case *object.Null:
-		return nil
+		return data.Value, nil
 	default:
-		return data
+		return strOf(data)
 	}
 }
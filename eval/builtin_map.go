@@ -0,0 +1,102 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+const (
+	mapMethodKeys   = "keys"
+	mapMethodValues = "values"
+	mapMethodHas    = "has"
+	mapMethodDelete = "delete"
+)
+
+// mapMethods are the methods of map: m.keys(), m.values(), m.has(k), m.delete(k), m.len()
+var mapMethods = map[string]func(m *object.Map, args []object.Object) (object.Object, error){
+	mapMethodKeys:   mapKeys,
+	mapMethodValues: mapValues,
+	mapMethodHas:    mapHas,
+	mapMethodDelete: mapDelete,
+	builtInLen:      mapLen,
+	methodIter:      mapIter,
+}
+
+func bindMapMethod(m *object.Map, name string) (*object.Builtin, bool) {
+	method, ok := mapMethods[name]
+	if !ok {
+		return nil, false
+	}
+
+	return &object.Builtin{
+		Name: name,
+		Fn: func(args ...object.Object) (object.Object, error) {
+			return method(m, args)
+		},
+	}, true
+}
+
+// m.iter() returns the iterator over the keys and values of m
+func mapIter(m *object.Map, args []object.Object) (object.Object, error) {
+	if err := checkArgsCount(methodIter, args, 0, 0); err != nil {
+		return nil, err
+	}
+	return mapIterator(m), nil
+}
+
+func mapLen(m *object.Map, args []object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInLen, args, 0, 0); err != nil {
+		return nil, err
+	}
+	return &object.Integer{Value: int64(m.Len())}, nil
+}
+
+func mapKeys(m *object.Map, args []object.Object) (object.Object, error) {
+	if err := checkArgsCount(mapMethodKeys, args, 0, 0); err != nil {
+		return nil, err
+	}
+
+	keys := make([]object.Object, 0, m.Len())
+	for _, pair := range m.Pairs() {
+		keys = append(keys, pair.Key)
+	}
+	return &object.Slice{Elements: keys}, nil
+}
+
+func mapValues(m *object.Map, args []object.Object) (object.Object, error) {
+	if err := checkArgsCount(mapMethodValues, args, 0, 0); err != nil {
+		return nil, err
+	}
+
+	values := make([]object.Object, 0, m.Len())
+	for _, pair := range m.Pairs() {
+		values = append(values, pair.Value)
+	}
+	return &object.Slice{Elements: values}, nil
+}
+
+func mapHas(m *object.Map, args []object.Object) (object.Object, error) {
+	if err := checkArgsCount(mapMethodHas, args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	_, ok, err := m.Get(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return &object.Bool{Value: ok}, nil
+}
+
+// delete(k) removes k from the map and reports whether it existed
+func mapDelete(m *object.Map, args []object.Object) (object.Object, error) {
+	if err := checkArgsCount(mapMethodDelete, args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	ok, err := m.Delete(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", mapMethodDelete, err)
+	}
+	return &object.Bool{Value: ok}, nil
+}
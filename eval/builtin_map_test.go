@@ -0,0 +1,102 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func TestMapBuiltins(t *testing.T) {
+	type args struct {
+		input string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want object.Object
+	}{
+		{
+			name: "literal and index",
+			args: args{
+				input: `
+				let m = {"a": 1, 2: "two", true: 3,};
+				return m["a"] + m[true] + len(m);
+				`,
+			},
+			want: &object.Integer{Value: 7},
+		},
+		{
+			name: "missing key is nil",
+			args: args{
+				input: `
+				let m = {};
+				return m["x"];
+				`,
+			},
+			want: &object.Null{},
+		},
+		{
+			name: "assign and keys keep insertion order",
+			args: args{
+				input: `
+				let m = {"b": 1};
+				m["a"] = 2;
+				m["b"] = 3;
+				return m.keys();
+				`,
+			},
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "b"},
+				&object.String{Value: "a"},
+			}},
+		},
+		{
+			name: "values has delete",
+			args: args{
+				input: `
+				let m = {"a": 1, "b": 2};
+				let deleted = m.delete("a");
+				if (deleted) {
+					if (m.has("a")) {
+						return 100;
+					}
+					return m.values();
+				}
+				return 0;
+				`,
+			},
+			want: intSlice(2),
+		},
+		{
+			name: "unhashable key",
+			args: args{
+				input: `
+				let m = {};
+				m[[1]] = 1;
+				`,
+			},
+			want: &object.Error{Message: "unhashable map key: [1]"},
+		},
+		{
+			name: "unknown method",
+			args: args{
+				input: `
+				let m = {};
+				return m.size();
+				`,
+			},
+			want: &object.Error{Message: "map has no method: size"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.args.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
@@ -0,0 +1,541 @@
+package eval
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+const (
+	builtInLen      = "len"
+	builtInPush     = "push"
+	builtInPop      = "pop"
+	builtInInsert   = "insert"
+	builtInRemove   = "remove"
+	builtInReverse  = "reverse"
+	builtInSort     = "sort"
+	builtInMap      = "map"
+	builtInFilter   = "filter"
+	builtInReduce   = "reduce"
+	builtInFind     = "find"
+	builtInContains = "contains"
+	builtInIndexOf  = "indexOf"
+	builtInConcat   = "concat"
+	builtInRange    = "range"
+)
+
+var (
+	ErrNotSlice           = "%s: %s is not a slice"
+	ErrNotInteger         = "%s: %s is not a integer"
+	ErrPopEmptySlice      = "pop from empty slice"
+	ErrRangeStepZero      = "range: step can not be zero"
+	ErrReduceEmptySlice   = "reduce of empty slice with no initial value"
+	ErrBadComparatorValue = "sort: comparator must return bool or integer, got: %s"
+)
+
+// sliceMethods are the builtins which can be called as a method of slice,
+// the slice is passed as the first argument: xs.push(1) is push(xs, 1)
+var sliceMethods = map[string]struct{}{
+	builtInAppend:   {},
+	builtInLen:      {},
+	builtInPush:     {},
+	builtInPop:      {},
+	builtInInsert:   {},
+	builtInRemove:   {},
+	builtInReverse:  {},
+	builtInSort:     {},
+	builtInMap:      {},
+	builtInFilter:   {},
+	builtInReduce:   {},
+	builtInFind:     {},
+	builtInContains: {},
+	builtInIndexOf:  {},
+	builtInConcat:   {},
+	builtInIter:     {},
+}
+
+func registerSliceBuiltins() {
+	registerBuiltin(builtInLen, builtinLen)
+	registerBuiltin(builtInPush, builtinPush)
+	registerBuiltin(builtInPop, builtinPop)
+	registerBuiltin(builtInInsert, builtinInsert)
+	registerBuiltin(builtInRemove, builtinRemove)
+	registerBuiltin(builtInReverse, builtinReverse)
+	registerBuiltin(builtInSort, builtinSort)
+	registerBuiltin(builtInMap, builtinMap)
+	registerBuiltin(builtInFilter, builtinFilter)
+	registerBuiltin(builtInReduce, builtinReduce)
+	registerBuiltin(builtInFind, builtinFind)
+	registerBuiltin(builtInContains, builtinContains)
+	registerBuiltin(builtInIndexOf, builtinIndexOf)
+	registerBuiltin(builtInConcat, builtinConcat)
+	registerBuiltin(builtInRange, builtinRange)
+}
+
+// bindSliceMethod returns the builtin `name` with sl bound as the first argument
+func bindSliceMethod(sl *object.Slice, name string) (*object.Builtin, bool) {
+	if _, ok := sliceMethods[name]; !ok {
+		return nil, false
+	}
+
+	b := builtins[name]
+	return &object.Builtin{
+		Name: name,
+		Fn: func(args ...object.Object) (object.Object, error) {
+			return b.Fn(append([]object.Object{sl}, args...)...)
+		},
+	}, true
+}
+
+func sliceArg(name string, arg object.Object) (*object.Slice, error) {
+	sl, ok := arg.(*object.Slice)
+	if !ok {
+		return nil, fmt.Errorf(ErrNotSlice, name, arg.Inspect())
+	}
+	return sl, nil
+}
+
+// mutableSliceArg is sliceArg for the builtins which change the elements in place
+func mutableSliceArg(name string, arg object.Object) (*object.Slice, error) {
+	sl, err := sliceArg(name, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	if sl.Frozen {
+		return nil, fmt.Errorf(object.ErrFrozen, "slice", sl.Inspect())
+	}
+	return sl, nil
+}
+
+func intArg(name string, arg object.Object) (int64, error) {
+	i, ok := arg.(*object.Integer)
+	if !ok {
+		return 0, fmt.Errorf(ErrNotInteger, name, arg.Inspect())
+	}
+	return i.Value, nil
+}
+
+func builtinLen(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInLen, args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	switch v := args[0].(type) {
+	case *object.Slice:
+		return &object.Integer{Value: int64(len(v.Elements))}, nil
+	case *object.String:
+		return &object.Integer{Value: int64(utf8.RuneCountInString(v.Value))}, nil
+	case *object.Map:
+		return &object.Integer{Value: int64(v.Len())}, nil
+	}
+
+	if method, ok := specialMethod(args[0], methodLen); ok {
+		n, err := applyFunction(method, nil)
+		if err != nil {
+			return nil, err
+		}
+		if _, isInt := n.(*object.Integer); !isInt {
+			return nil, fmt.Errorf("len: %s must return an integer, got: %s", methodLen, inspect(n))
+		}
+		return n, nil
+	}
+
+	return nil, fmt.Errorf("len: unsupported argument: %s", args[0].Inspect())
+}
+
+// push(xs, v...) appends values to the end of xs and returns xs
+func builtinPush(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInPush, args, 1, -1); err != nil {
+		return nil, err
+	}
+
+	sl, err := sliceArg(builtInPush, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sl.Append(args[1:]...); err != nil {
+		return nil, err
+	}
+	return sl, nil
+}
+
+// pop(xs) removes the last element of xs and returns it
+func builtinPop(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInPop, args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	sl, err := sliceArg(builtInPop, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sl.Elements) == 0 {
+		return nil, fmt.Errorf(ErrPopEmptySlice)
+	}
+	return sl.Remove(len(sl.Elements) - 1)
+}
+
+// insert(xs, idx, v) puts v at idx and returns xs
+func builtinInsert(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInInsert, args, 3, 3); err != nil {
+		return nil, err
+	}
+
+	sl, err := sliceArg(builtInInsert, args[0])
+	if err != nil {
+		return nil, err
+	}
+	idx, err := intArg(builtInInsert, args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sl.Insert(args[2], int(idx)); err != nil {
+		return nil, err
+	}
+	return sl, nil
+}
+
+// remove(xs, idx) deletes the element at idx and returns it
+func builtinRemove(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInRemove, args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	sl, err := sliceArg(builtInRemove, args[0])
+	if err != nil {
+		return nil, err
+	}
+	idx, err := intArg(builtInRemove, args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return sl.Remove(int(idx))
+}
+
+// reverse(xs) reverses xs in place and returns it
+func builtinReverse(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInReverse, args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	sl, err := mutableSliceArg(builtInReverse, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(sl.Elements)-1; i < j; i, j = i+1, j-1 {
+		sl.Elements[i], sl.Elements[j] = sl.Elements[j], sl.Elements[i]
+	}
+	return sl, nil
+}
+
+// sort(xs, cmp?) sorts xs in place and returns it.
+// cmp(a, b) returns true(or a negative integer) when a should be placed before b,
+// without cmp the elements are compared with `<`.
+func builtinSort(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInSort, args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	sl, err := mutableSliceArg(builtInSort, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	less := func(a, b object.Object) (bool, error) {
+		return compareObj(a, b, tokens.LT)
+	}
+	if len(args) == 2 {
+		cmp := args[1]
+		less = func(a, b object.Object) (bool, error) {
+			result, err := applyFunction(cmp, []object.Object{a, b})
+			if err != nil {
+				return false, err
+			}
+
+			switch v := result.(type) {
+			case *object.Bool:
+				return v.Value, nil
+			case *object.Integer:
+				return v.Value < 0, nil
+			}
+			return false, fmt.Errorf(ErrBadComparatorValue, inspect(result))
+		}
+	}
+
+	var sortErr error
+	sort.SliceStable(sl.Elements, func(i, j int) bool {
+		if sortErr != nil {
+			return false
+		}
+
+		ok, err := less(sl.Elements[i], sl.Elements[j])
+		if err != nil {
+			sortErr = err
+			return false
+		}
+		return ok
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	return sl, nil
+}
+
+// map(xs, fn) returns a new slice with fn(x) for each element
+func builtinMap(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInMap, args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	elements, err := iterableArg(builtInMap, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]object.Object, 0, len(elements))
+	for _, e := range elements {
+		v, err := applyFunction(args[1], []object.Object{e})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, v)
+	}
+
+	return &object.Slice{Elements: results}, nil
+}
+
+// filter(xs, fn) returns a new slice with the elements which fn(x) is true
+func builtinFilter(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInFilter, args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	elements, err := iterableArg(builtInFilter, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]object.Object, 0, len(elements))
+	for _, e := range elements {
+		ok, err := applyPredicate(builtInFilter, args[1], e)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			results = append(results, e)
+		}
+	}
+
+	return &object.Slice{Elements: results}, nil
+}
+
+// reduce(xs, fn, init?) folds xs from left to right with acc = fn(acc, x)
+func builtinReduce(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInReduce, args, 2, 3); err != nil {
+		return nil, err
+	}
+
+	elements, err := iterableArg(builtInReduce, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var acc object.Object
+	if len(args) == 3 {
+		acc = args[2]
+	} else {
+		if len(elements) == 0 {
+			return nil, fmt.Errorf(ErrReduceEmptySlice)
+		}
+		acc, elements = elements[0], elements[1:]
+	}
+
+	for _, e := range elements {
+		acc, err = applyFunction(args[1], []object.Object{acc, e})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return acc, nil
+}
+
+// find(xs, fn) returns the first element which fn(x) is true, or null
+func builtinFind(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInFind, args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	elements, err := iterableArg(builtInFind, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range elements {
+		ok, err := applyPredicate(builtInFind, args[1], e)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			return e, nil
+		}
+	}
+
+	return &object.Null{}, nil
+}
+
+func builtinContains(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInContains, args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	sl, err := sliceArg(builtInContains, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := indexOf(sl, args[1])
+	if err != nil {
+		return nil, err
+	}
+	return &object.Bool{Value: idx >= 0}, nil
+}
+
+// indexOf(xs, v) returns the index of the first element equals to v, or -1
+func builtinIndexOf(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInIndexOf, args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	sl, err := sliceArg(builtInIndexOf, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := indexOf(sl, args[1])
+	if err != nil {
+		return nil, err
+	}
+	return &object.Integer{Value: int64(idx)}, nil
+}
+
+// concat(xs, ys...) returns a new slice with the elements of all the slices
+func builtinConcat(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInConcat, args, 1, -1); err != nil {
+		return nil, err
+	}
+
+	elements := make([]object.Object, 0)
+	for _, arg := range args {
+		sl, err := sliceArg(builtInConcat, arg)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, sl.Elements...)
+	}
+
+	return &object.Slice{Elements: elements}, nil
+}
+
+// range(stop), range(start, stop), range(start, stop, step)
+func builtinRange(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInRange, args, 1, 3); err != nil {
+		return nil, err
+	}
+
+	nums := make([]int64, 0, len(args))
+	for _, arg := range args {
+		n, err := intArg(builtInRange, arg)
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, n)
+	}
+
+	var start, stop, step int64 = 0, nums[0], 1
+	if len(nums) > 1 {
+		start, stop = nums[0], nums[1]
+	}
+	if len(nums) > 2 {
+		step = nums[2]
+	}
+	if step == 0 {
+		return nil, fmt.Errorf(ErrRangeStepZero)
+	}
+
+	count := rangeCount(start, stop, step)
+	elements := make([]object.Object, 0, count)
+	for i, n := start, uint64(0); n < count; i, n = i+step, n+1 {
+		elements = append(elements, &object.Integer{Value: i})
+	}
+
+	return &object.Slice{Elements: elements}, nil
+}
+
+// rangeCount returns the number of the elements of range(start, stop, step),
+// it is computed in uint64 so the distance between start and stop does not overflow
+func rangeCount(start, stop, step int64) uint64 {
+	var distance, stride uint64
+	switch {
+	case step > 0 && start < stop:
+		distance, stride = uint64(stop)-uint64(start), uint64(step)
+	case step < 0 && start > stop:
+		distance, stride = uint64(start)-uint64(stop), -uint64(step)
+	default:
+		return 0
+	}
+	return (distance-1)/stride + 1
+}
+
+// applyPredicate calls fn with arg, the result is a condition like the one of if,
+// so it must be a bool value only in strict mode
+func applyPredicate(name string, fn object.Object, arg object.Object) (bool, error) {
+	result, err := applyFunction(fn, []object.Object{arg})
+	if err != nil {
+		return false, err
+	}
+
+	// the builtin callbacks do not belong to an interpreter, they use the default options
+	var env *object.Environment
+	if f, ok := fn.(*object.Function); ok {
+		env = f.Env
+	}
+
+	truth, err := truthy(result, env)
+	if err != nil {
+		return false, fmt.Errorf("%s: callback must return a bool value, got: %s", name, inspect(result))
+	}
+	return truth, nil
+}
+
+func indexOf(sl *object.Slice, target object.Object) (int, error) {
+	for idx, e := range sl.Elements {
+		eq, err := objectEquals(e, target)
+		if err != nil {
+			return -1, err
+		}
+		if eq {
+			return idx, nil
+		}
+	}
+	return -1, nil
+}
+
+func inspect(obj object.Object) string {
+	if obj == nil {
+		return "null"
+	}
+	return obj.Inspect()
+}
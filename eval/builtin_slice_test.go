@@ -0,0 +1,261 @@
+package eval
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func intSlice(values ...int64) *object.Slice {
+	elements := make([]object.Object, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, &object.Integer{Value: v})
+	}
+	return &object.Slice{Elements: elements}
+}
+
+func TestSliceBuiltins(t *testing.T) {
+	type args struct {
+		input string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want object.Object
+	}{
+		{
+			name: "len",
+			args: args{
+				input: `
+				let xs = [1, 2, 3];
+				return len(xs) + len("abcd") + xs.len();
+				`,
+			},
+			want: &object.Integer{Value: 10},
+		},
+		{
+			name: "push and pop",
+			args: args{
+				input: `
+				let xs = [1];
+				push(xs, 2, 3);
+				xs.push(4);
+				let last = xs.pop();
+				return last + len(xs);
+				`,
+			},
+			want: &object.Integer{Value: 7},
+		},
+		{
+			name: "pop empty slice",
+			args: args{
+				input: `
+				return pop([]);
+				`,
+			},
+			want: &object.Error{Message: ErrPopEmptySlice},
+		},
+		{
+			name: "insert and remove",
+			args: args{
+				input: `
+				let xs = [1, 3];
+				xs.insert(1, 2);
+				insert(xs, 3, 4);
+				xs.remove(0);
+				return xs;
+				`,
+			},
+			want: intSlice(2, 3, 4),
+		},
+		{
+			name: "remove out of bound",
+			args: args{
+				input: `
+				return remove([1], 1);
+				`,
+			},
+			want: &object.Error{Message: "idx: 1 is out of bound"},
+		},
+		{
+			name: "reverse",
+			args: args{
+				input: `
+				return [1, 2, 3].reverse();
+				`,
+			},
+			want: intSlice(3, 2, 1),
+		},
+		{
+			name: "sort",
+			args: args{
+				input: `
+				return sort([3, 1, 2]);
+				`,
+			},
+			want: intSlice(1, 2, 3),
+		},
+		{
+			name: "sort with comparator",
+			args: args{
+				input: `
+				fn desc(a, b) { return a > b; }
+				fn asc(a, b) { return a - b; }
+				let xs = [3, 1, 2].sort(desc);
+				return concat(xs, sort([6, 4, 5], asc));
+				`,
+			},
+			want: intSlice(3, 2, 1, 4, 5, 6),
+		},
+		{
+			name: "map filter reduce",
+			args: args{
+				input: `
+				fn double(x) { return x * 2; }
+				fn big(x) { return x > 4; }
+				fn add(acc, x) { return acc + x; }
+				return range(5).map(double).filter(big).reduce(add, 100);
+				`,
+			},
+			want: &object.Integer{Value: 114},
+		},
+		{
+			name: "callback is a closure",
+			args: args{
+				input: `
+				let base = 10;
+				fn addBase(x) { return x + base; }
+				return map([1, 2], addBase);
+				`,
+			},
+			want: intSlice(11, 12),
+		},
+		{
+			name: "callback with wrong arity",
+			args: args{
+				input: `
+				fn add(a, b) { return a + b; }
+				return map([1, 2], add);
+				`,
+			},
+			want: &object.Error{Message: "wrong number of arguments to add: got 1, want 2"},
+		},
+		{
+			name: "reduce without initial value",
+			args: args{
+				input: `
+				fn add(acc, x) { return acc + x; }
+				return reduce([1, 2, 3], add);
+				`,
+			},
+			want: &object.Integer{Value: 6},
+		},
+		{
+			name: "find",
+			args: args{
+				input: `
+				fn even(x) { return x / 2 * 2 == x; }
+				return [1, 3, 4, 6].find(even);
+				`,
+			},
+			want: &object.Integer{Value: 4},
+		},
+		{
+			name: "filter and find use truthiness",
+			args: args{
+				input: `
+				fn odd(x) { return x % 2; }
+				fn name(p) { return p["name"]; }
+				return [filter([1, 2, 3], odd), find([{"name": ""}, {"name": "a"}], name)["name"]];
+				`,
+			},
+			want: &object.Slice{Elements: []object.Object{
+				intSlice(1, 3),
+				&object.String{Value: "a"},
+			}},
+		},
+		{
+			name: "find nothing",
+			args: args{
+				input: `
+				fn neg(x) { return x < 0; }
+				return find([1, 2], neg);
+				`,
+			},
+			want: &object.Null{},
+		},
+		{
+			name: "contains and indexOf",
+			args: args{
+				input: `
+				let xs = ["a", "b"];
+				if (xs.contains("b")) {
+					return indexOf(xs, "b") + xs.indexOf("c");
+				}
+				return 100;
+				`,
+			},
+			want: &object.Integer{Value: 0},
+		},
+		{
+			name: "range with step",
+			args: args{
+				input: `
+				return concat(range(1, 4), range(10, 0, -5));
+				`,
+			},
+			want: intSlice(1, 2, 3, 10, 5),
+		},
+		{
+			name: "range near the int limits",
+			args: args{
+				input: `
+				let min = -9223372036854775807 - 1;
+				return concat(
+					range(9223372036854775806, 9223372036854775807, 5),
+					range(min + 1, min, -5),
+					range(min, 9223372036854775807, 9223372036854775807)
+				);
+				`,
+			},
+			want: &object.Slice{Elements: []object.Object{
+				&object.Integer{Value: math.MaxInt64 - 1},
+				&object.Integer{Value: math.MinInt64 + 1},
+				&object.Integer{Value: math.MinInt64},
+				&object.Integer{Value: -1},
+				&object.Integer{Value: math.MaxInt64 - 1},
+			}},
+		},
+		{
+			name: "wrong number of arguments",
+			args: args{
+				input: `
+				return range();
+				`,
+			},
+			want: &object.Error{Message: "wrong number of arguments to range: got 0, want 1 to 3"},
+		},
+		{
+			name: "builtin can be shadowed",
+			args: args{
+				input: `
+				fn len(x) { return 42; }
+				return len([1]);
+				`,
+			},
+			want: &object.Integer{Value: 42},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.args.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
@@ -1 +1,186 @@
 package eval
+
+import (
+	"fmt"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+// equalsMethod is the method which a class defines to compare its instances: equals(other)
+const equalsMethod = "equals"
+
+// objectEquals reports whether 2 objects hold the same value:
+//   - numbers are compared by value, 1 == 1.0
+//   - slices are equal when they have equal elements in the same order,
+//     maps are equal when they have the same keys and equal values
+//   - class instances are compared by the __eq__ or equals method of the class, or by identity if they are not defined
+//   - functions, classes and the other objects are compared by identity
+//
+// objects of different types are not equal.
+func objectEquals(obj1, obj2 object.Object) (bool, error) {
+	eq := &equality{comparing: map[objPair]bool{}}
+	return eq.equals(obj1, obj2)
+}
+
+type objPair struct {
+	left, right object.Object
+}
+
+// equality remembers the containers being compared, so it stops at the cycles
+type equality struct {
+	comparing map[objPair]bool
+}
+
+func (eq *equality) equals(obj1, obj2 object.Object) (bool, error) {
+	if obj1 == nil || obj2 == nil {
+		return obj1 == obj2, nil
+	}
+
+	if inst, ok := obj1.(*object.ClassInstance); ok {
+		for _, name := range []string{methodEq, equalsMethod} {
+			if _, defined := specialMethod(inst, name); defined {
+				return callBoolMethod(inst, name, obj2)
+			}
+		}
+	}
+
+	if isNumber(obj1) && isNumber(obj2) {
+		return compareNumbers(obj1, obj2, tokens.EQUAL), nil
+	}
+
+	if obj1.Type() != obj2.Type() {
+		return false, nil
+	}
+
+	switch left := obj1.(type) {
+	case *object.String:
+		return left.Value == obj2.(*object.String).Value, nil
+	case *object.Bool:
+		return left.Value == obj2.(*object.Bool).Value, nil
+	case *object.Null:
+		return true, nil
+	case *object.Slice:
+		return eq.slicesEqual(left, obj2.(*object.Slice))
+	case *object.Map:
+		return eq.mapsEqual(left, obj2.(*object.Map))
+	}
+
+	return obj1 == obj2, nil
+}
+
+func (eq *equality) slicesEqual(left, right *object.Slice) (bool, error) {
+	if left == right {
+		return true, nil
+	}
+	if len(left.Elements) != len(right.Elements) {
+		return false, nil
+	}
+
+	pair := objPair{left: left, right: right}
+	if eq.comparing[pair] {
+		return true, nil
+	}
+	eq.comparing[pair] = true
+	defer delete(eq.comparing, pair)
+
+	for idx, e := range left.Elements {
+		ok, err := eq.equals(e, right.Elements[idx])
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (eq *equality) mapsEqual(left, right *object.Map) (bool, error) {
+	if left == right {
+		return true, nil
+	}
+	if left.Len() != right.Len() {
+		return false, nil
+	}
+
+	pair := objPair{left: left, right: right}
+	if eq.comparing[pair] {
+		return true, nil
+	}
+	eq.comparing[pair] = true
+	defer delete(eq.comparing, pair)
+
+	for _, p := range left.Pairs() {
+		v, found, err := right.Get(p.Key)
+		if err != nil || !found {
+			return false, err
+		}
+
+		ok, err := eq.equals(p.Value, v)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// orderObj compares the order of 2 objects by op (<, <=, >, >=),
+// numbers, strings, slices and the instances of the classes which define __lt__ can be ordered,
+// slices are ordered by their elements one by one.
+func orderObj(obj1, obj2 object.Object, op tokens.TokenType) (bool, error) {
+	if inst, ok := obj1.(*object.ClassInstance); ok {
+		if result, defined, err := instanceOrder(inst, obj2, op); defined {
+			return result, err
+		}
+	}
+
+	if isNumber(obj1) && isNumber(obj2) {
+		return compareNumbers(obj1, obj2, op), nil
+	}
+
+	if obj1.Type() != obj2.Type() {
+		return false, fmt.Errorf("can not compare 2 different type: %s, %s", inspect(obj1), inspect(obj2))
+	}
+
+	switch left := obj1.(type) {
+	case *object.String:
+		return left.Compare(op, obj2.(*object.String)), nil
+	case *object.Slice:
+		return orderSlices(left, obj2.(*object.Slice), op)
+	}
+
+	return false, fmt.Errorf("unsupported compare type:  %v, %v", obj1.Type(), obj2.Type())
+}
+
+func orderSlices(left, right *object.Slice, op tokens.TokenType) (bool, error) {
+	for idx := 0; idx < len(left.Elements) && idx < len(right.Elements); idx++ {
+		l, r := left.Elements[idx], right.Elements[idx]
+		eq, err := objectEquals(l, r)
+		if err != nil {
+			return false, err
+		}
+		if !eq {
+			return orderObj(l, r, op)
+		}
+	}
+
+	// all the elements of the shorter slice are equal to the longer one, the shorter one is less
+	leftLen := &object.Integer{Value: int64(len(left.Elements))}
+	rightLen := &object.Integer{Value: int64(len(right.Elements))}
+	return leftLen.Compare(op, rightLen), nil
+}
+
+// compareNumbers compares 2 numbers of any type: integer, big integer or float
+func compareNumbers(obj1, obj2 object.Object, op tokens.TokenType) bool {
+	if obj1.Type() == object.OBJ_FLOAT || obj2.Type() == object.OBJ_FLOAT {
+		left, _ := toFloat(obj1)
+		right, _ := toFloat(obj2)
+		return (&object.Float{Value: left}).Compare(op, &object.Float{Value: right})
+	}
+
+	if obj1.Type() == object.OBJ_BIGINT || obj2.Type() == object.OBJ_BIGINT {
+		left, _ := toBigInt(obj1)
+		right, _ := toBigInt(obj2)
+		return compareBigInt(left, right, op)
+	}
+
+	return obj1.(*object.Integer).Compare(op, obj2.(*object.Integer))
+}
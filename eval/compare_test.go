@@ -0,0 +1,210 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func boolSlice(values ...bool) *object.Slice {
+	elements := make([]object.Object, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, &object.Bool{Value: v})
+	}
+	return &object.Slice{Elements: elements}
+}
+
+func TestEvalEquality(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "null and bool",
+			input: `
+			let x = null;
+			return [x == null, x != null, true == true, true == false, false != true];
+			`,
+			want: boolSlice(true, false, true, false, true),
+		},
+		{
+			name: "numbers",
+			input: `
+			return [1 == 1.0, 2 != 2, 1.5 == 1.5, 0.1 + 0.2 == 0.3];
+			`,
+			want: boolSlice(true, false, true, false),
+		},
+		{
+			name: "different types are not equal",
+			input: `
+			return [1 == "1", null == 0, false == null, [] == {}, "a" != 1];
+			`,
+			want: boolSlice(false, false, false, false, true),
+		},
+		{
+			name: "slices",
+			input: `
+			return [[1, [2, "3"]] == [1, [2, "3"]], [1, 2] == [2, 1], [1] == [1, 1], [] == []];
+			`,
+			want: boolSlice(true, false, false, true),
+		},
+		{
+			name: "maps",
+			input: `
+			let a = {"x": 1, "y": [1]};
+			let b = {"y": [1], "x": 1};
+			return [a == b, a == {"x": 1}, a == {"x": 1, "y": [2]}, {} == {}];
+			`,
+			want: boolSlice(true, false, false, true),
+		},
+		{
+			name: "cyclic slices",
+			input: `
+			let a = [1];
+			a.push(a);
+			let b = [1];
+			b.push(b);
+			return [a == b, a == a];
+			`,
+			want: boolSlice(true, true),
+		},
+		{
+			name: "instances by identity",
+			input: `
+			class P {}
+			let a = P();
+			let b = P();
+			return [a == a, a == b, a != b];
+			`,
+			want: boolSlice(true, false, true),
+		},
+		{
+			name: "instances by equals method",
+			input: `
+			class P {
+				equals(other) {
+					return other.x == this.x;
+				}
+			}
+			let a = P();
+			a.x = 1;
+			let b = P();
+			b.x = 1;
+			let c = P();
+			c.x = 2;
+			return [a == b, a != c, [a].contains(b), [c, b].indexOf(a) == 1];
+			`,
+			want: boolSlice(true, true, true, true),
+		},
+		{
+			name: "equals method must return bool",
+			input: `
+			class P {
+				equals(other) {
+					return 1;
+				}
+			}
+			return P() == P();
+			`,
+			want: &object.Error{Message: "P.equals must return a bool value, got: 1"},
+		},
+		{
+			name: "functions by identity",
+			input: `
+			fn f() {}
+			fn g() {}
+			return [f == f, f == g, len == len, len == push];
+			`,
+			want: boolSlice(true, false, true, false),
+		},
+		{
+			name: "match compares structurally",
+			input: `
+			return match ([1, 2] == [1, 2]) {
+				true => "same",
+				false => "different",
+			};
+			`,
+			want: &object.String{Value: "same"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestEvalOrdering(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "numbers",
+			input: `
+			return [1 < 1.5, 2.0 >= 2, 3 > 1, -1 <= -2];
+			`,
+			want: boolSlice(true, true, true, false),
+		},
+		{
+			name: "strings",
+			input: `
+			return ["a" < "b", "b" <= "a", "abc" > "ab"];
+			`,
+			want: boolSlice(true, false, true),
+		},
+		{
+			name: "slices",
+			input: `
+			return [[1, 2] < [1, 3], [1, 2] < [1, 2, 0], [2] > [1, 9], [1, 2] <= [1, 2], ["b"] < ["a"]];
+			`,
+			want: boolSlice(true, true, true, true, false),
+		},
+		{
+			name: "sort slices",
+			input: `
+			return sort([[2, 1], [1, 5], [1]]);
+			`,
+			want: &object.Slice{Elements: []object.Object{intSlice(1), intSlice(1, 5), intSlice(2, 1)}},
+		},
+		{
+			name: "different types can not be ordered",
+			input: `
+			return 1 < "a";
+			`,
+			want: &object.Error{Message: "can not compare 2 different type: 1, a"},
+		},
+		{
+			name: "unordered type",
+			input: `
+			return true < false;
+			`,
+			want: &object.Error{Message: "unsupported compare type:  BOOL, BOOL"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestEvalBigIntEquality(t *testing.T) {
+	obj, err := testEvalWithOptions(`
+	let big = 9223372036854775807 + 1;
+	return [big == big - 1 + 1, big > 9223372036854775807, big == 9223372036854775807, big == 9223372036854775807.0 + 1];
+	`, &Options{IntOverflow: OverflowBigInt})
+	assert.Nil(t, err)
+	assert.Equal(t, boolSlice(true, true, false, true), obj)
+}
@@ -0,0 +1,121 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+// errShortCircuit stops an optional chain when the object before `?.` is null
+var errShortCircuit = errors.New("optional chain short circuit")
+
+func isNull(obj object.Object) bool {
+	return obj == nil || obj.Type() == object.OBJ_NULL
+}
+
+// isTruthy is the truthiness of the value used by the conditions:
+// null, false, 0, 0.0, "", the empty slice and the empty map are falsy, the others are truthy.
+func isTruthy(obj object.Object) bool {
+	switch v := obj.(type) {
+	case nil, *object.Null:
+		return false
+	case *object.Bool:
+		return v.Value
+	case *object.Integer:
+		return v.Value != 0
+	case *object.Float:
+		return v.Value != 0
+	case *object.String:
+		return v.Value != ""
+	case *object.Slice:
+		return len(v.Elements) > 0
+	case *object.Map:
+		return v.Len() > 0
+	}
+	return true
+}
+
+// truthy returns whether the condition is true,
+// in strict mode the condition must be a bool value.
+func truthy(cond object.Object, env *object.Environment) (bool, error) {
+	if !optionsOf(env).StrictBool {
+		return isTruthy(cond), nil
+	}
+
+	truth, ok := cond.(*object.Bool)
+	if !ok {
+		return false, fmt.Errorf(ErrCondMustBeBoolValue, inspect(cond))
+	}
+	return truth.Value, nil
+}
+
+// evalCondition evaluates the expression used as a condition
+func evalCondition(expr ast.Expression, env *object.Environment) (bool, error) {
+	cond, err := Eval(expr, env)
+	if err != nil {
+		return false, err
+	}
+	return truthy(cond, env)
+}
+
+// cond ? then : else, only one of then and else is evaluated
+func evalTernary(t *ast.Ternary, env *object.Environment) (object.Object, error) {
+	truth, err := evalCondition(t.Condition, env)
+	if err != nil {
+		return nil, err
+	}
+
+	if truth {
+		return Eval(t.Then, env)
+	}
+	return Eval(t.Else, env)
+}
+
+// evalLogical evaluates a && b, a || b and a ?? b,
+// the right operand is evaluated only when the left one does not decide the result,
+// and the result is the value of the last evaluated operand.
+func evalLogical(lg *ast.Logical, env *object.Environment) (object.Object, error) {
+	left, err := Eval(lg.Left, env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch lg.Operator.TkType {
+	case tokens.DQUESTION:
+		if isNull(left) {
+			return Eval(lg.Right, env)
+		}
+		return left, nil
+	case tokens.AND, tokens.OR:
+		truth, err := truthy(left, env)
+		if err != nil {
+			return nil, err
+		}
+		if truth == (lg.Operator.TkType == tokens.OR) {
+			return left, nil
+		}
+
+		right, err := Eval(lg.Right, env)
+		if err != nil {
+			return nil, err
+		}
+		// in strict mode the result of && and || is always a bool value
+		if _, err := truthy(right, env); err != nil {
+			return nil, err
+		}
+		return right, nil
+	}
+
+	return nil, fmt.Errorf(ErrNotSupportedOperator, lg.Operator.Literal)
+}
+
+func evalOptionalChain(oc *ast.OptionalChain, env *object.Environment) (object.Object, error) {
+	obj, err := Eval(oc.Expr, env)
+	if errors.Is(err, errShortCircuit) {
+		return &object.Null{}, nil
+	}
+	return obj, err
+}
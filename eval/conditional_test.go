@@ -0,0 +1,275 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func TestEvalConditional(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "ternary",
+			input: `
+			let x = 5;
+			return x > 3 ? "big" : "small";
+			`,
+			want: &object.String{Value: "big"},
+		},
+		{
+			name: "nested ternary is right associative",
+			input: `
+			fn size(n) { return n < 10 ? "s" : n < 100 ? "m" : "l"; }
+			return size(1) + size(50) + size(500);
+			`,
+			want: &object.String{Value: "sml"},
+		},
+		{
+			name: "ternary evaluates one branch",
+			input: `
+			let xs = [];
+			let v = true ? xs.push(1) : xs.push(2);
+			return xs;
+			`,
+			want: intSlice(1),
+		},
+		{
+			name: "ternary condition uses truthiness",
+			input: `
+			return [1 ? 2 : 3, "" ? 2 : 3];
+			`,
+			want: intSlice(2, 3),
+		},
+		{
+			name: "null coalescing",
+			input: `
+			let m = {"a": 1};
+			return [m["a"] ?? 10, m["b"] ?? 10, null ?? null ?? 3];
+			`,
+			want: intSlice(1, 10, 3),
+		},
+		{
+			name: "null coalescing evaluates right only when left is null",
+			input: `
+			let xs = [];
+			let v = 1 ?? xs.push(1);
+			return len(xs);
+			`,
+			want: &object.Integer{Value: 0},
+		},
+		{
+			name: "ternary and null coalescing precedence",
+			input: `
+			let a = null;
+			return a ?? 1 == 1 ? "yes" : "no";
+			`,
+			want: &object.String{Value: "yes"},
+		},
+		{
+			name: "optional chaining on instance",
+			input: `
+			class Node {
+				name() { return "node"; }
+			}
+			let n = Node();
+			n.next = null;
+			return [n?.name(), n.next?.name(), n.next?.value.deep];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "node"},
+				&object.Null{},
+				&object.Null{},
+			}},
+		},
+		{
+			name: "optional chaining with default",
+			input: `
+			let user = {"name": "x"};
+			let missing = user["address"];
+			return missing?.keys() ?? "no address";
+			`,
+			want: &object.String{Value: "no address"},
+		},
+		{
+			name: "plain get on null still fails",
+			input: `
+			let a = null;
+			return a.b;
+			`,
+			want: &object.Error{Message: "expr: null can not get property, only class instance have property"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestEvalTruthiness(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "falsy values",
+			input: `
+			return [!null, !false, !0, !0.0, !"", ![], !{}];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.Bool{Value: true},
+				&object.Bool{Value: true},
+				&object.Bool{Value: true},
+				&object.Bool{Value: true},
+				&object.Bool{Value: true},
+				&object.Bool{Value: true},
+				&object.Bool{Value: true},
+			}},
+		},
+		{
+			name: "truthy values",
+			input: `
+			class A {}
+			return [!!1, !!-1.5, !!"0", !![0], !!{"a": 1}, !!A(), !!len];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.Bool{Value: true},
+				&object.Bool{Value: true},
+				&object.Bool{Value: true},
+				&object.Bool{Value: true},
+				&object.Bool{Value: true},
+				&object.Bool{Value: true},
+				&object.Bool{Value: true},
+			}},
+		},
+		{
+			name: "if and while conditions",
+			input: `
+			let xs = [1, 2, 3];
+			let sum = 0;
+			while (xs) {
+				sum += xs.pop();
+			}
+			if (sum) {
+				return sum;
+			}
+			return -1;
+			`,
+			want: &object.Integer{Value: 6},
+		},
+		{
+			name: "and or return operand values",
+			input: `
+			return [0 || "default", 5 || "default", 0 && 1, 2 && 3, null || 0 || 7];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "default"},
+				&object.Integer{Value: 5},
+				&object.Integer{Value: 0},
+				&object.Integer{Value: 3},
+				&object.Integer{Value: 7},
+			}},
+		},
+		{
+			name: "and or short circuit",
+			input: `
+			let calls = 0;
+			fn touch() { calls += 1; return true; }
+			let a = false && touch();
+			let b = true || touch();
+			let c = true && touch();
+			let d = false || touch();
+			return calls;
+			`,
+			want: &object.Integer{Value: 2},
+		},
+		{
+			name: "and binds tighter than or",
+			input: `
+			return true || false && false;
+			`,
+			want: &object.Bool{Value: true},
+		},
+		{
+			name: "match guard",
+			input: `
+			let xs = [];
+			return match (xs) {
+				v if v => "not empty",
+				_ => "empty",
+			};
+			`,
+			want: &object.String{Value: "empty"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestEvalStrictBool(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name:  "bool conditions",
+			input: `if (1 < 2 && !false) { return true || false; }`,
+			want:  &object.Bool{Value: true},
+		},
+		{
+			name:  "if condition",
+			input: `if (1) { return 1; }`,
+			want:  &object.Error{Message: "condition must be a bool value: 1"},
+		},
+		{
+			name:  "while condition",
+			input: `while ("x") { return 1; }`,
+			want:  &object.Error{Message: "condition must be a bool value: x"},
+		},
+		{
+			name:  "ternary condition",
+			input: `return null ? 1 : 2;`,
+			want:  &object.Error{Message: "condition must be a bool value: null"},
+		},
+		{
+			name:  "bang operand",
+			input: `return !0;`,
+			want:  &object.Error{Message: "right value must be boolean: &{0}"},
+		},
+		{
+			name:  "filter callback",
+			input: `fn odd(x) { return x % 2; } return [1, 2].filter(odd);`,
+			want:  &object.Error{Message: "filter: callback must return a bool value, got: 1"},
+		},
+		{
+			name:  "logical operand",
+			input: `return true && 1;`,
+			want:  &object.Error{Message: "condition must be a bool value: 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalWithOptions(tt.input, &Options{StrictBool: true})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
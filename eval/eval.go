@@ -1,8 +1,10 @@
 package eval
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
 
 	"github.com/forfd8960/simpleinterpreter/ast"
 	"github.com/forfd8960/simpleinterpreter/object"
@@ -12,6 +14,7 @@ import (
 var (
 	ErrNodeNotLiteral                = "node: %v is not literal"
 	ErrNotIntegerValue               = "value: %v is not integer"
+	ErrNotFloatValue                 = "value: %v is not float"
 	ErrNotBoolValue                  = "value: %v is not boolean"
 	ErrNotStringValue                = "value: %v is not string"
 	ErrDivideByZero                  = "integer divide by zero"
@@ -24,12 +27,15 @@ var (
 	ErrIDentIsNotSlice               = "identifier: %s is not a slice"
 	ErrIdxIsNotInteger               = "idx: %s is not a integer"
 	ErrIdxOutOfBound                 = "idx: %d out of bound, total length is: %d"
+	ErrSliceStepZero                 = "slice step can not be zero"
+	ErrMapMethodNotFound             = "map has no method: %s"
+	ErrSliceMethodNotFound           = "slice has no method: %s"
 )
 
 func Eval(node ast.Node, env *object.Environment) (object.Object, error) {
 	switch v := node.(type) {
 	case *ast.Program:
-		return evalStatements(v.Stmts, env)
+		return evalProgram(v, env)
 	case *ast.ClassStmt:
 		return evalClassStmt(v, env)
 	case *ast.Function:
@@ -50,6 +56,10 @@ func Eval(node ast.Node, env *object.Environment) (object.Object, error) {
 		return evalIfStmt(v, env)
 	case *ast.ReturnStmt:
 		return evalReturn(v, env)
+	case *ast.BreakStmt:
+		return &object.Break{}, nil
+	case *ast.YieldStmt:
+		return evalYield(v, env)
 	case *ast.PrintStmt:
 		return evalPrintStmt(v, env)
 	case *ast.ExpressionStmt:
@@ -58,8 +68,12 @@ func Eval(node ast.Node, env *object.Environment) (object.Object, error) {
 		return evalGroup(v, env)
 	case *ast.Slice:
 		return evalSlice(v, env)
+	case *ast.MapLiteral:
+		return evalMapLiteral(v, env)
 	case *ast.SliceAccess:
 		return evalSliceAccess(v, env)
+	case *ast.SliceRange:
+		return evalSliceRange(v, env)
 	case *ast.Literal:
 		return evalLiteral(v)
 	case *ast.Binary:
@@ -68,19 +82,48 @@ func Eval(node ast.Node, env *object.Environment) (object.Object, error) {
 		return evalUnary(v, env)
 	case *ast.Call:
 		return evalCall(v, env)
-	case *ast.DExp: // a--, b++
+	case *ast.DExp: // a--, b++, ++a, --b
 		return evalDExp(v, env)
+	case *ast.CompoundAssign: // a += 1
+		return evalCompoundAssign(v, env)
 	case *ast.Get: // classObj.property
 		return evalGetStmt(v, env)
 	case *ast.Set: // classObj.property = value
 		return evalSetStmt(v, env)
 	case *ast.ThisExpr:
 		return evalThisExpr(v, env)
+	case *ast.Ternary:
+		return evalTernary(v, env)
+	case *ast.Logical:
+		return evalLogical(v, env)
+	case *ast.OptionalChain:
+		return evalOptionalChain(v, env)
+	case *ast.ForStmt:
+		return Eval(v.Loop, env)
+	case *ast.ForInStmt:
+		return evalForInStmt(v, env)
+	case *ast.Match:
+		return evalMatch(v, env)
+	case *ast.ImportStmt:
+		return evalImportStmt(v, env)
+	case *ast.ExportStmt:
+		return Eval(v.Stmt, env)
 	}
 
 	return nil, nil
 }
 
+func evalProgram(program *ast.Program, env *object.Environment) (object.Object, error) {
+	it := interpreterOf(env)
+	if err := Resolve(program); err != nil {
+		return newError(err.Error()), nil
+	}
+	if it.opts.Optimizer != nil {
+		program = it.opts.Optimizer(program, it.opts)
+	}
+	return evalStatements(program.Stmts, env)
+}
+
 func evalStatements(nodes []ast.Stmt, env *object.Environment) (object.Object, error) {
 	var result object.Object
 	var err error
@@ -92,6 +135,11 @@ func evalStatements(nodes []ast.Stmt, env *object.Environment) (object.Object, e
 		}
 
 		if err != nil {
+			// os.exit stops the whole script, it is not an error of the script
+			var exit *ExitError
+			if errors.As(err, &exit) {
+				return nil, err
+			}
 			return newError(err.Error()), nil
 		}
 	}
@@ -123,10 +171,19 @@ func evalFunctionStmt(astFn *ast.Function, env *object.Environment) (*object.Fun
 		params = append(params, ast.NewIdentifier(token))
 	}
 
+	var rest *ast.Identifier
+	if astFn.Rest != nil {
+		rest = ast.NewIdentifier(astFn.Rest)
+	}
+
 	fn := &object.Function{
+		Name:       astFn.Name.Literal,
 		Parameters: params,
+		Defaults:   astFn.Defaults,
+		Rest:       rest,
 		Body:       astFn.Body,
 		Env:        env,
+		Generator:  astFn.Generator,
 	}
 
 	// register to env, and call expression can find the function object later
@@ -140,7 +197,10 @@ func newError(format string, args ...interface{}) *object.Error {
 	}
 }
 
-func evalBockStmts(b *ast.Block, env *object.Environment) (object.Object, error) {
+func evalBockStmts(b *ast.Block, outter *object.Environment) (object.Object, error) {
+	// every block has its own scope
+	env := object.NewBlockEnv(outter)
+
 	var obj object.Object
 	var err error
 	for _, stmt := range b.Statements {
@@ -149,7 +209,7 @@ func evalBockStmts(b *ast.Block, env *object.Environment) (object.Object, error)
 			return nil, err
 		}
 
-		if obj != nil && obj.Type() == object.OBJ_RETURN {
+		if obj != nil && (obj.Type() == object.OBJ_RETURN || obj.Type() == object.OBJ_BREAK) {
 			return obj, nil
 		}
 	}
@@ -160,17 +220,12 @@ func evalBockStmts(b *ast.Block, env *object.Environment) (object.Object, error)
 func evalWhileStmt(wl *ast.WhileStmt, env *object.Environment) (object.Object, error) {
 	var result object.Object
 	for {
-		cond, err := Eval(wl.Condition, env)
+		truth, err := evalCondition(wl.Condition, env)
 		if err != nil {
 			return nil, err
 		}
 
-		truth, ok := cond.(*object.Bool)
-		if !ok {
-			return nil, fmt.Errorf(ErrCondMustBeBoolValue, cond)
-		}
-
-		if !truth.Value {
+		if !truth {
 			break
 		}
 
@@ -183,6 +238,9 @@ func evalWhileStmt(wl *ast.WhileStmt, env *object.Environment) (object.Object, e
 		if isRet {
 			return ret, nil
 		}
+		if _, isBreak := result.(*object.Break); isBreak {
+			return nil, nil
+		}
 	}
 
 	return result, nil
@@ -194,7 +252,9 @@ func evalLetStmt(let *ast.LetStmt, env *object.Environment) (object.Object, erro
 		return nil, err
 	}
 
-	env.Set(let.Ident.Name, obj)
+	if err := env.Define(let.Ident.Name, obj, let.Const); err != nil {
+		return nil, err
+	}
 	return obj, nil
 }
 
@@ -204,7 +264,9 @@ func evalAssign(assign *ast.Assign, env *object.Environment) (object.Object, err
 		return nil, err
 	}
 
-	env.Set(assign.Name.Literal, obj)
+	if err := env.Assign(assign.Name.Literal, obj); err != nil {
+		return nil, err
+	}
 	return obj, nil
 }
 
@@ -214,15 +276,24 @@ func evalSliceElementAssign(sea *ast.SliceElementAssign, env *object.Environment
 		return nil, err
 	}
 
+	idxObj, err := Eval(sea.SLA.Idx, env)
+	if err != nil {
+		return nil, err
+	}
+
+	if m, isMap := slObj.(*object.Map); isMap {
+		setObj, err := Eval(sea.Value, env)
+		if err != nil {
+			return nil, err
+		}
+		return setObj, m.Set(idxObj, setObj)
+	}
+
 	sl, ok := slObj.(*object.Slice)
 	if !ok {
 		return nil, fmt.Errorf(ErrIDentIsNotSlice, sea.SLA.Name.TokenLiteral())
 	}
 
-	idxObj, err := Eval(sea.SLA.Idx, env)
-	if err != nil {
-		return nil, err
-	}
 	idx, ok := idxObj.(*object.Integer)
 	if !ok {
 		return nil, fmt.Errorf(ErrIdxIsNotInteger, idxObj.Inspect())
@@ -233,13 +304,21 @@ func evalSliceElementAssign(sea *ast.SliceElementAssign, env *object.Environment
 		return nil, err
 	}
 
-	err = sl.Set(setObj, int(idx.Value))
+	i, err := normalizeIndex(idx.Value, len(sl.Elements))
+	if err != nil {
+		return nil, err
+	}
+
+	err = sl.Set(setObj, i)
 	return setObj, err
 }
 
 func evalIdent(ident *ast.Identifier, env *object.Environment) (object.Object, error) {
 	obj, ok := env.Get(ident.Name)
 	if !ok {
+		if b, isBuiltin := lookupBuiltin(ident.Name, env); isBuiltin {
+			return b, nil
+		}
 		return nil, fmt.Errorf(ErrIdentifierNotFound, ident.Name)
 	}
 
@@ -247,16 +326,12 @@ func evalIdent(ident *ast.Identifier, env *object.Environment) (object.Object, e
 }
 
 func evalIfStmt(v *ast.IFStmt, env *object.Environment) (object.Object, error) {
-	cond, err := Eval(v.Condition, env)
+	truth, err := evalCondition(v.Condition, env)
 	if err != nil {
 		return nil, err
 	}
-	truth, ok := cond.(*object.Bool)
-	if !ok {
-		return nil, fmt.Errorf("bad if condition: %v", cond)
-	}
 
-	if truth.Value {
+	if truth {
 		return Eval(v.ThenBranch, env)
 	} else if v.ElseBranch != nil {
 		return Eval(v.ElseBranch, env)
@@ -266,7 +341,13 @@ func evalIfStmt(v *ast.IFStmt, env *object.Environment) (object.Object, error) {
 }
 
 func evalReturn(v *ast.ReturnStmt, env *object.Environment) (object.Object, error) {
-	result, err := Eval(v.Value, env)
+	var result object.Object
+	var err error
+	if v.TailCall {
+		result, err = evalTailCall(v.Value.(*ast.Call), env)
+	} else {
+		result, err = Eval(v.Value, env)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -298,30 +379,193 @@ func evalSlice(sl *ast.Slice, env *object.Environment) (object.Object, error) {
 	return &object.Slice{Elements: elements}, nil
 }
 
+func evalMapLiteral(ml *ast.MapLiteral, env *object.Environment) (object.Object, error) {
+	m := object.NewMap()
+	for _, pair := range ml.Pairs {
+		key, err := Eval(pair.Key, env)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := Eval(pair.Value, env)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := m.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
 func evalSliceAccess(sa *ast.SliceAccess, env *object.Environment) (object.Object, error) {
 	slObj, err := Eval(sa.Name, env)
 	if err != nil {
 		return nil, err
 	}
-	sl, ok := slObj.(*object.Slice)
-	if !ok {
-		return nil, fmt.Errorf(ErrIDentIsNotSlice, sa.Name.TokenLiteral())
-	}
 
 	idxObj, err := Eval(sa.Idx, env)
 	if err != nil {
 		return nil, err
 	}
+
+	if method, ok := specialMethod(slObj, methodIndex); ok {
+		return applyFunction(method, []object.Object{idxObj})
+	}
+
+	if m, isMap := slObj.(*object.Map); isMap {
+		v, ok, err := m.Get(idxObj)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return &object.Null{}, nil
+		}
+		return v, nil
+	}
+
 	idx, ok := idxObj.(*object.Integer)
 	if !ok {
 		return nil, fmt.Errorf(ErrIdxIsNotInteger, idxObj.Inspect())
 	}
 
-	if idx.Value < 0 || idx.Value >= int64(len(sl.Elements)) {
-		return nil, fmt.Errorf(ErrIdxOutOfBound, idx.Value, len(sl.Elements))
+	switch v := slObj.(type) {
+	case *object.Slice:
+		i, err := normalizeIndex(idx.Value, len(v.Elements))
+		if err != nil {
+			return nil, err
+		}
+		return v.Elements[i], nil
+	case *object.String:
+		runes := []rune(v.Value)
+		i, err := normalizeIndex(idx.Value, len(runes))
+		if err != nil {
+			return nil, err
+		}
+		return &object.String{Value: string(runes[i])}, nil
+	}
+
+	return nil, fmt.Errorf(ErrIDentIsNotSlice, sa.Name.TokenLiteral())
+}
+
+// normalizeIndex turns a negative index into the index counted from the end,
+// -1 is the last element.
+func normalizeIndex(idx int64, length int) (int, error) {
+	i := idx
+	if i < 0 {
+		i += int64(length)
+	}
+
+	if i < 0 || i >= int64(length) {
+		return 0, fmt.Errorf(ErrIdxOutOfBound, idx, length)
+	}
+	return int(i), nil
+}
+
+// evalSliceRange evaluates xs[low:high:step] with the python semantic:
+// the bounds are optional, can be negative and are clamped to the length.
+// A sub slice is a copy, modify the sub slice does not change the original one.
+func evalSliceRange(sr *ast.SliceRange, env *object.Environment) (object.Object, error) {
+	slObj, err := Eval(sr.Name, env)
+	if err != nil {
+		return nil, err
+	}
+
+	var length int
+	switch v := slObj.(type) {
+	case *object.Slice:
+		length = len(v.Elements)
+	case *object.String:
+		length = len([]rune(v.Value))
+	default:
+		return nil, fmt.Errorf(ErrIDentIsNotSlice, sr.Name.TokenLiteral())
+	}
+
+	bounds := make([]*int64, 0, 3)
+	for _, e := range []ast.Expression{sr.Low, sr.High, sr.Step} {
+		if e == nil {
+			bounds = append(bounds, nil)
+			continue
+		}
+
+		obj, err := Eval(e, env)
+		if err != nil {
+			return nil, err
+		}
+		i, ok := obj.(*object.Integer)
+		if !ok {
+			return nil, fmt.Errorf(ErrIdxIsNotInteger, inspect(obj))
+		}
+		bounds = append(bounds, &i.Value)
+	}
+
+	indexes, err := sliceRangeIndexes(length, bounds[0], bounds[1], bounds[2])
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := slObj.(type) {
+	case *object.Slice:
+		elements := make([]object.Object, 0, len(indexes))
+		for _, i := range indexes {
+			elements = append(elements, v.Elements[i])
+		}
+		return &object.Slice{Elements: elements}, nil
+	default:
+		runes := []rune(slObj.(*object.String).Value)
+		sub := make([]rune, 0, len(indexes))
+		for _, i := range indexes {
+			sub = append(sub, runes[i])
+		}
+		return &object.String{Value: string(sub)}, nil
+	}
+}
+
+// sliceRangeIndexes returns the indexes selected by [low:high:step] on a sequence with length
+func sliceRangeIndexes(length int, low, high, step *int64) ([]int, error) {
+	n := int64(length)
+	st := int64(1)
+	if step != nil {
+		st = *step
+	}
+	if st == 0 {
+		return nil, fmt.Errorf(ErrSliceStepZero)
+	}
+
+	clamp := func(bound *int64, def, min, max int64) int64 {
+		if bound == nil {
+			return def
+		}
+
+		v := *bound
+		if v < 0 {
+			v += n
+		}
+		if v < min {
+			return min
+		}
+		if v > max {
+			return max
+		}
+		return v
+	}
+
+	var start, stop int64
+	if st > 0 {
+		start, stop = clamp(low, 0, 0, n), clamp(high, n, 0, n)
+	} else {
+		start, stop = clamp(low, n-1, -1, n-1), clamp(high, -1, -1, n-1)
 	}
 
-	return sl.Elements[idx.Value], nil
+	// a huge step would overflow i, so the indexes are counted up front like range
+	count := rangeCount(start, stop, st)
+	indexes := make([]int, 0, count)
+	for i, k := start, uint64(0); k < count; i, k = i+st, k+1 {
+		indexes = append(indexes, int(i))
+	}
+	return indexes, nil
 }
 
 func evalLiteral(literal *ast.Literal) (object.Object, error) {
@@ -329,11 +573,13 @@ func evalLiteral(literal *ast.Literal) (object.Object, error) {
 	switch value.TkType {
 	case tokens.INTEGER:
 		return evalLiteralInteger(value.Value)
+	case tokens.FLOAT:
+		return evalLiteralFloat(value.Value)
 	case tokens.TRUE, tokens.FALSE:
 		return evalLiteralBool(value.Value)
 	case tokens.STRING:
 		return evalLiteralString(value.Value)
-	case tokens.NIL:
+	case tokens.NULL:
 		return evalLiteralNull(value.Value)
 	}
 
@@ -350,48 +596,58 @@ func evalBinary(bin *ast.Binary, env *object.Environment) (object.Object, error)
 		return nil, err
 	}
 
-	op := bin.Operator.TkType
+	return binaryOp(leftResult, rightResult, bin.Operator.TkType, env)
+}
+
+// binaryOp applies the binary operator op to the evaluated operands
+func binaryOp(leftResult, rightResult object.Object, op tokens.TokenType, env *object.Environment) (object.Object, error) {
+	if method, ok := specialMethod(leftResult, arithmeticMethods[op]); ok {
+		return applyFunction(method, []object.Object{rightResult})
+	}
 
+	var result object.Object
+	var err error
 	switch op {
 	case tokens.GT, tokens.GTEQ, tokens.LT, tokens.LTEQ, tokens.NOTEQUAL, tokens.EQUAL:
 		resul, err := compareObj(leftResult, rightResult, op)
 		return &object.Bool{Value: resul}, err
 	case tokens.PLUS:
-		return plusObj(leftResult, rightResult)
+		result, err = plusObj(leftResult, rightResult)
 	default:
-		return doMath(leftResult, rightResult, op)
+		result, err = doMath(leftResult, rightResult, op)
 	}
-}
 
-func compareObj(obj1, obj2 object.Object, op tokens.TokenType) (bool, error) {
-	if obj1.Type() != obj2.Type() {
-		return false, fmt.Errorf("can not compare 2 different type: %v, %v", obj1, obj2)
+	var overflow *IntegerOverflowError
+	if errors.As(err, &overflow) && optionsOf(env).IntOverflow == OverflowBigInt {
+		return doBigMath(leftResult, rightResult, op)
 	}
+	return result, err
+}
 
-	switch obj1.Type() {
-	case object.OBJ_INTEGER:
-		left, _ := obj1.(*object.Integer)
-		right, _ := obj2.(*object.Integer)
-		return left.Compare(op, right), nil
-	case object.OBJ_STRING:
-		left, _ := obj1.(*object.String)
-		right, _ := obj2.(*object.String)
-		return left.Compare(op, right), nil
+// compareObj compares 2 objects by op, == and != are defined for all the objects, see objectEquals
+func compareObj(obj1, obj2 object.Object, op tokens.TokenType) (bool, error) {
+	switch op {
+	case tokens.EQUAL:
+		return objectEquals(obj1, obj2)
+	case tokens.NOTEQUAL:
+		eq, err := objectEquals(obj1, obj2)
+		return !eq, err
 	}
-
-	return false, fmt.Errorf("unsupported compare type:  %v, %v", obj1.Type(), obj2.Type())
+	return orderObj(obj1, obj2, op)
 }
 
 func plusObj(obj1, obj2 object.Object) (object.Object, error) {
+	if isNumber(obj1) && isNumber(obj2) && obj1.Type() != obj2.Type() {
+		return doMath(obj1, obj2, tokens.PLUS)
+	}
+
 	if obj1.Type() != obj2.Type() {
 		return nil, fmt.Errorf("can not plus 2 different type: %v, %v", obj1, obj2)
 	}
 
 	switch obj1.Type() {
-	case object.OBJ_INTEGER:
-		left, _ := obj1.(*object.Integer)
-		right, _ := obj2.(*object.Integer)
-		return &object.Integer{Value: left.Value + right.Value}, nil
+	case object.OBJ_INTEGER, object.OBJ_BIGINT, object.OBJ_FLOAT:
+		return doMath(obj1, obj2, tokens.PLUS)
 	case object.OBJ_STRING:
 		left, _ := obj1.(*object.String)
 		right, _ := obj2.(*object.String)
@@ -402,32 +658,98 @@ func plusObj(obj1, obj2 object.Object) (object.Object, error) {
 }
 
 func doMath(obj1, obj2 object.Object, op tokens.TokenType) (object.Object, error) {
-	leftValue, ok := obj1.(*object.Integer)
-	if !ok {
+	if !isNumber(obj1) {
 		return nil, fmt.Errorf("%v must be number", obj1.Inspect())
 	}
-	rightValue, ok := obj2.(*object.Integer)
-	if !ok {
+	if !isNumber(obj2) {
 		return nil, fmt.Errorf("%v must be number", obj2.Inspect())
 	}
 
+	if obj1.Type() == object.OBJ_FLOAT || obj2.Type() == object.OBJ_FLOAT {
+		left, _ := toFloat(obj1)
+		right, _ := toFloat(obj2)
+		return doFloatMath(left, right, op)
+	}
+
+	if obj1.Type() == object.OBJ_BIGINT || obj2.Type() == object.OBJ_BIGINT {
+		return doBigMath(obj1, obj2, op)
+	}
+
+	left := obj1.(*object.Integer).Value
+	right := obj2.(*object.Integer).Value
+
+	var v int64
+	var ok bool
 	switch op {
+	case tokens.PLUS:
+		v, ok = addInt(left, right)
 	case tokens.MINUS:
-		return &object.Integer{Value: leftValue.Value - rightValue.Value}, nil
+		v, ok = subInt(left, right)
 	case tokens.ASTERISK:
-		return &object.Integer{Value: leftValue.Value * rightValue.Value}, nil
+		v, ok = mulInt(left, right)
 	case tokens.POW:
-		return &object.Integer{Value: int64(math.Pow(float64(leftValue.Value), float64(rightValue.Value)))}, nil
+		if right < 0 {
+			return &object.Float{Value: math.Pow(float64(left), float64(right))}, nil
+		}
+		v, ok = powInt(left, right)
 	case tokens.SLASH:
-		if rightValue.Value == 0 {
+		if right == 0 {
 			return nil, fmt.Errorf(ErrDivideByZero)
 		}
-		return &object.Integer{Value: leftValue.Value / rightValue.Value}, nil
+		v, ok = divInt(left, right)
+	case tokens.PERCENT:
+		if right == 0 {
+			return nil, fmt.Errorf(ErrDivideByZero)
+		}
+		v, ok = left%right, true
+	default:
+		return nil, fmt.Errorf("unsupported operator: %s", op.String())
+	}
+
+	if !ok {
+		return nil, newOverflowError(left, op, right)
+	}
+	return &object.Integer{Value: v}, nil
+}
+
+func doFloatMath(left, right float64, op tokens.TokenType) (object.Object, error) {
+	switch op {
+	case tokens.PLUS:
+		return &object.Float{Value: left + right}, nil
+	case tokens.MINUS:
+		return &object.Float{Value: left - right}, nil
+	case tokens.ASTERISK:
+		return &object.Float{Value: left * right}, nil
+	case tokens.POW:
+		return &object.Float{Value: math.Pow(left, right)}, nil
+	case tokens.SLASH:
+		return &object.Float{Value: left / right}, nil
+	case tokens.PERCENT:
+		return &object.Float{Value: math.Mod(left, right)}, nil
 	}
 
 	return nil, fmt.Errorf("unsupported operator: %s", op.String())
 }
 
+func isNumber(obj object.Object) bool {
+	_, ok := toFloat(obj)
+	return ok
+}
+
+func toFloat(obj object.Object) (float64, bool) {
+	switch v := obj.(type) {
+	case *object.Integer:
+		return float64(v.Value), true
+	case *object.BigInt:
+		f, _ := new(big.Float).SetInt(v.Value).Float64()
+		return f, true
+	case *object.Float:
+		return v.Value, true
+	}
+
+	return 0, false
+}
+
 func evalUnary(node *ast.Unary, env *object.Environment) (object.Object, error) {
 	op := node.Operator
 	obj, err := Eval(node.Right, env)
@@ -437,114 +759,171 @@ func evalUnary(node *ast.Unary, env *object.Environment) (object.Object, error)
 
 	switch op.TkType {
 	case tokens.BANG:
-		v, ok := obj.(*object.Bool)
-		if !ok {
-			return nil, fmt.Errorf("right value must be boolean: %v", obj)
+		if optionsOf(env).StrictBool {
+			v, ok := obj.(*object.Bool)
+			if !ok {
+				return nil, fmt.Errorf("right value must be boolean: %v", obj)
+			}
+			return &object.Bool{Value: !v.Value}, nil
 		}
-		return &object.Bool{Value: !v.Value}, nil
+		return &object.Bool{Value: !isTruthy(obj)}, nil
 	case tokens.MINUS:
-		v, ok := obj.(*object.Integer)
-		if !ok {
-			return nil, fmt.Errorf("right value must be integer: %v", obj)
+		if method, ok := specialMethod(obj, methodNeg); ok {
+			return applyFunction(method, nil)
 		}
-		return &object.Integer{Value: -v.Value}, nil
+
+		switch v := obj.(type) {
+		case *object.Integer:
+			if v.Value == math.MinInt64 {
+				if optionsOf(env).IntOverflow == OverflowBigInt {
+					return normalizeBigInt(new(big.Int).Neg(big.NewInt(v.Value))), nil
+				}
+				return nil, &IntegerOverflowError{Expr: fmt.Sprintf("-(%d)", v.Value)}
+			}
+			return &object.Integer{Value: -v.Value}, nil
+		case *object.BigInt:
+			return normalizeBigInt(new(big.Int).Neg(v.Value)), nil
+		case *object.Float:
+			return &object.Float{Value: -v.Value}, nil
+		}
+		return nil, fmt.Errorf("right value must be number: %v", obj)
 	}
 
 	return nil, fmt.Errorf("unsupported unary Operator: %v", op)
 }
 
 func evalCall(callExpr *ast.Call, globalEnv *object.Environment) (object.Object, error) {
-	callName := callExpr.Callee.TokenLiteral()
-	if IsBuiltInFunction(callName) {
-		return evalBuildInFunctions(callName, callExpr, globalEnv)
-	}
-
 	// callExpr.Callee is a identifier, and after Eval, it should return a function object
 	callee, err := Eval(callExpr.Callee, globalEnv)
 	if err != nil {
 		return nil, err
 	}
+	return callObject(callee, callExpr, globalEnv)
+}
 
+// callObject calls the evaluated callee with the arguments of callExpr
+func callObject(callee object.Object, callExpr *ast.Call, globalEnv *object.Environment) (object.Object, error) {
 	switch v := callee.(type) {
 	case *object.Class:
 		return evalCallClass(v, callExpr, globalEnv)
 	case *object.Function:
 		return evalCallFunction(v, callExpr, globalEnv)
+	case *object.Builtin:
+		args, kwargs, err := evalArguments(callExpr.Arguments, globalEnv)
+		if err != nil {
+			return nil, err
+		}
+		if len(kwargs) > 0 {
+			return nil, fmt.Errorf(ErrNoKeywordArguments, v.Name)
+		}
+		return v.Fn(args...)
 	}
 
-	return nil, fmt.Errorf(ErrIdentifierIsNotCallable, callee.Inspect())
+	return nil, fmt.Errorf(ErrIdentifierIsNotCallable, inspect(callee))
 }
 
-func evalDExp(dexp *ast.DExp, env *object.Environment) (object.Object, error) {
-	oneLiteral, _ := ast.NewLiteral1(1)
+func evalCallClass(cls *object.Class, callExpr *ast.Call, globalEnv *object.Environment) (object.Object, error) {
+	return object.NewClassInstance(cls), nil
+}
 
-	switch dexp.Operator.TkType {
-	case tokens.DPlus:
-		return evalBinary(
-			ast.NewBinary(
-				dexp.Left,
-				oneLiteral,
-				tokens.OPPlus,
-			),
-			env,
-		)
-	case tokens.DMinus:
-		return evalBinary(
-			ast.NewBinary(
-				dexp.Left,
-				oneLiteral,
-				tokens.OPMinus,
-			),
-			env,
-		)
+func evalCallFunction(fn *object.Function, callExpr *ast.Call, globalEnv *object.Environment) (object.Object, error) {
+	args, kwargs, err := evalArguments(callExpr.Arguments, globalEnv)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("unsupported operator: %+v", dexp.Operator)
+	return callFunctionWith(fn, args, kwargs)
 }
 
-func evalCallClass(cls *object.Class, callExpr *ast.Call, globalEnv *object.Environment) (object.Object, error) {
-	return object.NewClassInstance(cls), nil
+// applyFunction calls the callable object with evaluated arguments,
+// builtins use it to call the callbacks passed by scripts.
+func applyFunction(callee object.Object, args []object.Object) (object.Object, error) {
+	switch fn := callee.(type) {
+	case *object.Function:
+		return callFunction(fn, args)
+	case *object.Builtin:
+		return fn.Fn(args...)
+	case *object.Class:
+		return object.NewClassInstance(fn), nil
+	}
+
+	return nil, fmt.Errorf(ErrIdentifierIsNotCallable, inspect(callee))
 }
 
-func evalCallFunction(fn *object.Function, callExpr *ast.Call, globalEnv *object.Environment) (object.Object, error) {
-	if len(fn.Parameters) != len(callExpr.Arguments) {
-		return nil, fmt.Errorf("not engough params to function: %s, need %d arguments", fn.Inspect(), len(fn.Parameters))
-	}
+func callFunction(fn *object.Function, args []object.Object) (object.Object, error) {
+	return callFunctionWith(fn, args, nil)
+}
 
-	var env = object.NewEnvWithOutter(fn.Env)
-	if len(fn.Parameters) > 0 {
-		for idx, param := range fn.Parameters {
-			v, err := Eval(callExpr.Arguments[idx], globalEnv)
-			if err != nil {
-				return nil, err
+// callFunctionWith calls fn, and then the functions which it returns as tail calls, one after another
+func callFunctionWith(fn *object.Function, args []object.Object, kwargs []keywordArg) (object.Object, error) {
+	for {
+		env, err := bindArguments(fn, args, kwargs)
+		if err != nil {
+			return nil, err
+		}
+
+		if fn.Generator {
+			return newGenerator(fn, env), nil
+		}
+
+		obj, err := Eval(fn.Body, env)
+		if err != nil {
+			return nil, err
+		}
+
+		var result object.Object
+		if obj != nil {
+			result = obj
+			for result.Type() == object.OBJ_RETURN {
+				v := result.(*object.Return)
+				result = v.Value
 			}
+		}
 
-			fmt.Printf("setting %s with value: %+v\n", param.Name, v)
-			env.Set(param.Name, v)
+		tc, isTailCall := result.(*tailCall)
+		if !isTailCall {
+			return result, nil
 		}
+		fn, args, kwargs = tc.fn, tc.args, tc.kwargs
 	}
+}
 
-	obj, err := Eval(fn.Body, env)
+func evalGetStmt(get *ast.Get, env *object.Environment) (object.Object, error) {
+	instanceObj, err := Eval(get.Expr, env)
 	if err != nil {
 		return nil, err
 	}
 
-	var result object.Object
-	if obj != nil {
-		result = obj
-		for result.Type() == object.OBJ_RETURN {
-			v := result.(*object.Return)
-			result = v.Value
+	if get.Optional && isNull(instanceObj) {
+		return nil, errShortCircuit
+	}
+
+	if m, isModule := instanceObj.(*object.Module); isModule {
+		return m.Get(get.Name)
+	}
+
+	if m, isMap := instanceObj.(*object.Map); isMap {
+		method, ok := bindMapMethod(m, get.Name.Literal)
+		if !ok {
+			return nil, fmt.Errorf(ErrMapMethodNotFound, get.Name.Literal)
 		}
+		return method, nil
 	}
 
-	return result, nil
-}
+	if it, isIterator := instanceObj.(*object.Iterator); isIterator {
+		method, ok := bindIteratorMethod(it, get.Name.Literal)
+		if !ok {
+			return nil, fmt.Errorf("iterator has no method: %s", get.Name.Literal)
+		}
+		return method, nil
+	}
 
-func evalGetStmt(get *ast.Get, env *object.Environment) (object.Object, error) {
-	instanceObj, err := Eval(get.Expr, env)
-	if err != nil {
-		return nil, err
+	if sl, isSlice := instanceObj.(*object.Slice); isSlice {
+		method, ok := bindSliceMethod(sl, get.Name.Literal)
+		if !ok {
+			return nil, fmt.Errorf(ErrSliceMethodNotFound, get.Name.Literal)
+		}
+		return method, nil
 	}
 
 	clsInst, ok := instanceObj.(*object.ClassInstance)
@@ -576,7 +955,9 @@ func evalSetStmt(set *ast.Set, env *object.Environment) (object.Object, error) {
 		return nil, err
 	}
 
-	clsInstance.Set(set.Name, value)
+	if err := clsInstance.Set(set.Name, value); err != nil {
+		return nil, err
+	}
 	return value, nil
 }
 
@@ -598,6 +979,14 @@ func evalLiteralInteger(value interface{}) (*object.Integer, error) {
 	return &object.Integer{Value: v}, nil
 }
 
+func evalLiteralFloat(value interface{}) (*object.Float, error) {
+	v, ok := value.(float64)
+	if !ok {
+		return nil, fmt.Errorf(ErrNotFloatValue, value)
+	}
+	return &object.Float{Value: v}, nil
+}
+
 func evalLiteralBool(value interface{}) (*object.Bool, error) {
 	v, ok := value.(bool)
 	if !ok {
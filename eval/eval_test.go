@@ -16,6 +16,7 @@ import (
 
 func testEvalInput(input string) (object.Object, error) {
 	env := object.NewEnvironment()
+	defer Close(env)
 	tokens, err := lexer.TokensFromInput(input)
 	if err != nil {
 		return nil, err
@@ -140,6 +141,7 @@ func TestEvalFunction(t *testing.T) {
 
 	fnEnv := object.NewEnvironment()
 	fnEnv.Set("add", &object.Function{
+		Name: "add",
 		Parameters: []*ast.Identifier{
 			ast.NewIdentifier(tokens.NewToken(tokens.IDENT, "x", "x")),
 		},
@@ -170,6 +172,7 @@ func TestEvalFunction(t *testing.T) {
 				`,
 			},
 			want: &object.Function{
+				Name: "add",
 				Parameters: []*ast.Identifier{
 					ast.NewIdentifier(tokens.NewToken(tokens.IDENT, "x", "x")),
 				},
@@ -220,7 +223,7 @@ func TestEvalFunction(t *testing.T) {
 				return add(10);
 				`,
 			},
-			want:    &object.Error{Message: "not engough params to function: fn(x,y), need 2 arguments"},
+			want:    &object.Error{Message: "wrong number of arguments to add: got 1, want 2"},
 			wantErr: true,
 		},
 	}
@@ -469,13 +472,23 @@ func TestEvalUnary(t *testing.T) {
 			want: &object.Integer{Value: -10},
 		},
 		{
-			name: "eval unary err",
+			name: "eval unary truthiness",
 			args: args{
 				input: ast.NewUnary(
 					tokens.NewToken(tokens.BANG, "!", "!"),
 					ast.NewLiteral(tokens.NewToken(tokens.INTEGER, "10", int64(10))),
 				),
 			},
+			want: &object.Bool{Value: false},
+		},
+		{
+			name: "eval unary err",
+			args: args{
+				input: ast.NewUnary(
+					tokens.NewToken(tokens.MINUS, "-", "-"),
+					ast.NewLiteral(tokens.NewToken(tokens.STRING, "10", "10")),
+				),
+			},
 			wantErr: true,
 			want:    nil,
 		},
@@ -820,3 +833,281 @@ func TestEvalCallClass(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalSliceIndexAndRange(t *testing.T) {
+	type args struct {
+		input string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want object.Object
+	}{
+		{
+			name: "negative index",
+			args: args{
+				input: `
+				let xs = [1, 2, 3];
+				return xs[-1] + xs[-3];
+				`,
+			},
+			want: &object.Integer{Value: 4},
+		},
+		{
+			name: "negative index out of bound",
+			args: args{
+				input: `
+				let xs = [1, 2, 3];
+				return xs[-4];
+				`,
+			},
+			want: &object.Error{Message: "idx: -4 out of bound, total length is: 3"},
+		},
+		{
+			name: "assign with negative index",
+			args: args{
+				input: `
+				let xs = [1, 2, 3];
+				xs[-1] = 10;
+				return xs;
+				`,
+			},
+			want: intSlice(1, 2, 10),
+		},
+		{
+			name: "sub slice",
+			args: args{
+				input: `
+				let xs = [0, 1, 2, 3, 4];
+				return concat(xs[1:3], xs[:1], xs[4:], xs[-2:]);
+				`,
+			},
+			want: intSlice(1, 2, 0, 4, 3, 4),
+		},
+		{
+			name: "sub slice with step",
+			args: args{
+				input: `
+				let xs = [0, 1, 2, 3, 4];
+				return concat(xs[::2], xs[::-1], xs[3:0:-2]);
+				`,
+			},
+			want: intSlice(0, 2, 4, 4, 3, 2, 1, 0, 3, 1),
+		},
+		{
+			name: "sub slice with huge step",
+			args: args{
+				input: `
+				let xs = [0, 1, 2, 3, 4];
+				return concat(xs[1::9223372036854775807], xs[3::-9223372036854775807]);
+				`,
+			},
+			want: intSlice(1, 3),
+		},
+		{
+			name: "sub slice bounds are clamped",
+			args: args{
+				input: `
+				let xs = [0, 1, 2];
+				return concat(xs[-10:10], xs[5:], xs[2:1]);
+				`,
+			},
+			want: intSlice(0, 1, 2),
+		},
+		{
+			name: "sub slice is a copy",
+			args: args{
+				input: `
+				let xs = [0, 1, 2];
+				let ys = xs[:2];
+				ys[0] = 100;
+				ys.push(200);
+				return xs;
+				`,
+			},
+			want: intSlice(0, 1, 2),
+		},
+		{
+			name: "zero step",
+			args: args{
+				input: `
+				return [1][::0];
+				`,
+			},
+			want: &object.Error{Message: ErrSliceStepZero},
+		},
+		{
+			name: "string index",
+			args: args{
+				input: `
+				let s = "héllo";
+				return s[1] + s[-1];
+				`,
+			},
+			want: &object.String{Value: "éo"},
+		},
+		{
+			name: "sub string",
+			args: args{
+				input: `
+				let s = "héllo";
+				return s[1:3] + s[::-1];
+				`,
+			},
+			want: &object.String{Value: "élolléh"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.args.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestEvalConstAndFreeze(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "const",
+			input: `
+			const x = 10;
+			return x + 1;
+			`,
+			want: &object.Integer{Value: 11},
+		},
+		{
+			name: "let in loop body is a new variable in every iteration",
+			input: `
+			let sum = 0;
+			for (i = 0; i < 3; i = i + 1) {
+				const double = i * 2;
+				sum = sum + double;
+			}
+			return sum;
+			`,
+			want: &object.Integer{Value: 6},
+		},
+		{
+			name: "assign updates the outter variable",
+			input: `
+			let count = 0;
+			fn incr() {
+				count = count + 1;
+			}
+			incr();
+			incr();
+			return count;
+			`,
+			want: &object.Integer{Value: 2},
+		},
+		{
+			name: "let in block is not visible after it",
+			input: `
+			if (true) { let y = 5; }
+			return y;
+			`,
+			want: &object.Error{Message: "identifier: y is not found"},
+		},
+		{
+			name: "assign in block declares the name in the enclosing scope",
+			input: `
+			if (true) { y = 5; }
+			for (x in [1]) { z = x; }
+			fn f() {
+				while (true) { w = 1; break; }
+				return w;
+			}
+			return [y, z, f()];
+			`,
+			want: intSlice(5, 1, 1),
+		},
+		{
+			name: "assign in function does not declare a global name",
+			input: `
+			fn f() { if (true) { v = 1; } }
+			f();
+			return v;
+			`,
+			want: &object.Error{Message: "identifier: v is not found"},
+		},
+		{
+			name: "freeze slice",
+			input: `
+			let xs = freeze([1, [2]]);
+			xs[1].push(3);
+			`,
+			want: &object.Error{Message: "can not modify frozen slice: [2]"},
+		},
+		{
+			name: "freeze map",
+			input: `
+			let m = freeze({"a": 1});
+			m["b"] = 2;
+			`,
+			want: &object.Error{Message: "can not modify frozen map: {\"a\": 1}"},
+		},
+		{
+			name: "freeze instance",
+			input: `
+			class Point {}
+			let p = Point();
+			p.x = 1;
+			freeze(p);
+			p.x = 2;
+			`,
+			want: &object.Error{Message: "can not modify frozen instance: Point{x: 1}"},
+		},
+		{
+			name: "sort frozen slice",
+			input: `
+			return sort(freeze([2, 1]));
+			`,
+			want: &object.Error{Message: "can not modify frozen slice: [2, 1]"},
+		},
+		{
+			name: "frozen value can be read and copied",
+			input: `
+			let xs = freeze([3, 1, 2]);
+			let ys = xs[:];
+			ys.sort();
+			return ys[0] + xs[0];
+			`,
+			want: &object.Integer{Value: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestEvalConstAcrossPrograms(t *testing.T) {
+	env := NewEnvironment(nil)
+	run := func(input string) object.Object {
+		tokens, err := lexer.TokensFromInput(input)
+		assert.Nil(t, err)
+
+		program, err := parser.NewParser(tokens).ParseProgram()
+		assert.Nil(t, err)
+
+		obj, err := Eval(program, env)
+		assert.Nil(t, err)
+		return obj
+	}
+
+	run(`const x = 1; let y = 2;`)
+	assert.Equal(t, &object.Error{Message: "can not assign to constant: x"}, run(`x = 2;`))
+	assert.Equal(t, &object.Error{Message: "identifier: y is already declared"}, run(`let y = 3;`))
+	assert.Equal(t, &object.Integer{Value: 1}, run(`return x;`))
+}
@@ -0,0 +1,233 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+const (
+	moduleFS = "fs"
+	// maxSymlinks is the number of the dangling symlinks which realPath follows, like the limit of the kernel
+	maxSymlinks = 40
+)
+
+var (
+	ErrFSNotAllowed = "%s: access to %s is not allowed"
+	ErrFSReadOnly   = "%s: file system is read-only"
+)
+
+func newFSModule(caps *Capabilities) *object.Module {
+	fns := map[string]object.BuiltinFunction{
+		"readFile":  fsReadFile(caps),
+		"writeFile": fsWriteFile(caps),
+		"listDir":   fsListDir(caps),
+		"exists":    fsExists(caps),
+	}
+
+	members := make(map[string]object.Object, len(fns))
+	for name, fn := range fns {
+		members[name] = &object.Builtin{Name: moduleFS + "." + name, Fn: fn}
+	}
+	return object.NewModule(moduleFS, members)
+}
+
+// fs.readFile(path) returns the content of the file as string
+func fsReadFile(caps *Capabilities) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		path, err := fsPathArg("fs.readFile", caps, args, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("fs.readFile: %w", err)
+		}
+		return &object.String{Value: string(bs)}, nil
+	}
+}
+
+// fs.writeFile(path, content) creates or truncates the file, then writes content to it
+func fsWriteFile(caps *Capabilities) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		path, err := fsPathArg("fs.writeFile", caps, args, 2)
+		if err != nil {
+			return nil, err
+		}
+
+		if caps.ReadOnly {
+			return nil, fmt.Errorf(ErrFSReadOnly, "fs.writeFile")
+		}
+
+		content, ok := args[1].(*object.String)
+		if !ok {
+			return nil, fmt.Errorf("fs.writeFile: %s is not a string", inspect(args[1]))
+		}
+
+		if err := os.WriteFile(path, []byte(content.Value), 0o644); err != nil {
+			return nil, fmt.Errorf("fs.writeFile: %w", err)
+		}
+		return &object.Null{}, nil
+	}
+}
+
+// fs.listDir(path) returns the names of the entries in the directory, sorted by name
+func fsListDir(caps *Capabilities) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		path, err := fsPathArg("fs.listDir", caps, args, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("fs.listDir: %w", err)
+		}
+
+		names := make([]object.Object, 0, len(entries))
+		for _, e := range entries {
+			names = append(names, &object.String{Value: e.Name()})
+		}
+		return &object.Slice{Elements: names}, nil
+	}
+}
+
+func fsExists(caps *Capabilities) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		path, err := fsPathArg("fs.exists", caps, args, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = os.Stat(path)
+		if err == nil {
+			return &object.Bool{Value: true}, nil
+		}
+		if errors.Is(err, fs.ErrNotExist) {
+			return &object.Bool{Value: false}, nil
+		}
+		return nil, fmt.Errorf("fs.exists: %w", err)
+	}
+}
+
+// fsPathArg checks the arguments count, and returns the first argument as a path inside the allowed roots
+func fsPathArg(name string, caps *Capabilities, args []object.Object, count int) (string, error) {
+	if err := checkArgsCount(name, args, count, count); err != nil {
+		return "", err
+	}
+
+	path, ok := args[0].(*object.String)
+	if !ok {
+		return "", fmt.Errorf("%s: %s is not a string", name, inspect(args[0]))
+	}
+	return sandboxPath(name, caps, path.Value)
+}
+
+// sandboxPath resolves path and the symlinks in it,
+// and returns an error if the result is not inside any of the allowed roots
+func sandboxPath(name string, caps *Capabilities, path string) (string, error) {
+	if len(caps.FSRoots) == 0 {
+		return "", fmt.Errorf(ErrFSNotAllowed, name, path)
+	}
+
+	p := path
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(caps.FSRoots[0], p)
+	}
+
+	p, err := filepath.Abs(p)
+	if err != nil {
+		return "", err
+	}
+
+	real, err := realPath(p)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+
+	if inRoots(caps.FSRoots, real) {
+		return real, nil
+	}
+	return "", fmt.Errorf(ErrFSNotAllowed, name, path)
+}
+
+// inRoots reports whether the resolved path is inside any of the roots
+func inRoots(roots []string, real string) bool {
+	for _, root := range roots {
+		r, err := filepath.Abs(root)
+		if err != nil {
+			continue
+		}
+		if r, err = filepath.EvalSymlinks(r); err != nil {
+			continue
+		}
+
+		if isSubPath(r, real) {
+			return true
+		}
+	}
+	return false
+}
+
+// realPath resolves the symlinks in path, the part of path which does not exist is kept as it is.
+// a dangling symlink is resolved to its target, so the file created through it is checked where it is created
+func realPath(path string) (string, error) {
+	var missing []string
+	for links := 0; ; {
+		real, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			return filepath.Join(append([]string{real}, missing...)...), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+
+		if info, lerr := os.Lstat(path); lerr == nil && info.Mode()&fs.ModeSymlink != 0 {
+			if links++; links > maxSymlinks {
+				return "", fmt.Errorf("too many links: %s", path)
+			}
+			if path, err = readLink(path); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return "", err
+		}
+		missing = append([]string{filepath.Base(path)}, missing...)
+		path = parent
+	}
+}
+
+// readLink returns the target of the symlink, the relative target is resolved against the real dir of the link
+func readLink(link string) (string, error) {
+	target, err := os.Readlink(link)
+	if err != nil {
+		return "", err
+	}
+	if filepath.IsAbs(target) {
+		return target, nil
+	}
+
+	dir, err := realPath(filepath.Dir(link))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, target), nil
+}
+
+func isSubPath(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,129 @@
+package eval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func TestFSModule(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0o644))
+	assert.Nil(t, os.Symlink(outside, filepath.Join(root, "link")))
+
+	tests := []struct {
+		name  string
+		input string
+		caps  Capabilities
+		want  object.Object
+	}{
+		{
+			name: "write read list exists",
+			input: `
+			fs.writeFile("a.txt", "hello");
+			if (fs.exists("b.txt")) {
+				return "unexpected";
+			}
+			return concat(fs.listDir("."), [fs.readFile("a.txt")]);
+			`,
+			caps: Capabilities{FSRoots: []string{root}},
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "a.txt"},
+				&object.String{Value: "link"},
+				&object.String{Value: "hello"},
+			}},
+		},
+		{
+			name: "no roots",
+			input: `
+			return fs.exists("a.txt");
+			`,
+			want: &object.Error{Message: "fs.exists: access to a.txt is not allowed"},
+		},
+		{
+			name: "path outside roots",
+			input: `
+			return fs.readFile("../x.txt");
+			`,
+			caps: Capabilities{FSRoots: []string{root}},
+			want: &object.Error{Message: "fs.readFile: access to ../x.txt is not allowed"},
+		},
+		{
+			name: "symlink outside roots",
+			input: `
+			return fs.readFile("link/secret.txt");
+			`,
+			caps: Capabilities{FSRoots: []string{root}},
+			want: &object.Error{Message: "fs.readFile: access to link/secret.txt is not allowed"},
+		},
+		{
+			name: "read only",
+			input: `
+			return fs.writeFile("a.txt", "x");
+			`,
+			caps: Capabilities{FSRoots: []string{root}, ReadOnly: true},
+			want: &object.Error{Message: "fs.writeFile: file system is read-only"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalWithOptions(tt.input, &Options{Capabilities: tt.caps})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestFSDanglingSymlink(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	assert.Nil(t, os.Symlink(filepath.Join(outside, "pwned.txt"), filepath.Join(root, "out")))
+	assert.Nil(t, os.Symlink("new.txt", filepath.Join(root, "in")))
+	assert.Nil(t, os.Symlink(filepath.Join(outside, "dir"), filepath.Join(root, "outdir")))
+	assert.Nil(t, os.Symlink("loop", filepath.Join(root, "loop")))
+
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name:  "link to a missing file outside roots",
+			input: `return fs.writeFile("out", "x");`,
+			want:  &object.Error{Message: "fs.writeFile: access to out is not allowed"},
+		},
+		{
+			name:  "link to a missing dir outside roots",
+			input: `return fs.writeFile("outdir/a.txt", "x");`,
+			want:  &object.Error{Message: "fs.writeFile: access to outdir/a.txt is not allowed"},
+		},
+		{
+			name:  "link to a missing file inside roots",
+			input: `fs.writeFile("in", "x"); return fs.readFile("new.txt");`,
+			want:  &object.String{Value: "x"},
+		},
+		{
+			name:  "link loop",
+			input: `return fs.writeFile("loop", "x");`,
+			want:  &object.Error{Message: "fs.writeFile: EvalSymlinks: too many links"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalWithOptions(tt.input, &Options{Capabilities: Capabilities{FSRoots: []string{root}}})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+
+	entries, err := os.ReadDir(outside)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,214 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+// errGeneratorClosed unwinds the body of a generator which is closed while it is suspended at yield
+var errGeneratorClosed = errors.New("generator is closed")
+
+// generatorStep is what the body of a generator hands to the caller of next()
+type generatorStep struct {
+	value object.Object
+	done  bool // the body returned, value is the return value
+	err   error
+}
+
+/*
+generator runs the body of a generator function in its own goroutine, one step for each next():
+next() resumes the body and waits, the body runs until the next yield, hands the value to next() and waits.
+Only one of them runs at a time, so the body and the caller never touch the interpreter state together.
+
+The goroutine starts at the first next(), and exits when the body returns or fails, or when the generator is closed:
+by it.close(), by the for-in loop over it, by Close when the host is done with the interpreter,
+or by the garbage collector after the script drops the generator.
+*/
+type generator struct {
+	fn      *object.Function
+	env     *object.Environment
+	resume  chan struct{}
+	steps   chan generatorStep
+	stop    chan struct{}
+	stopped sync.Once
+
+	// live is the set of the generators of the interpreter, which Close closes
+	live *generatorSet
+
+	// they are only used by the caller of next()
+	started  bool
+	finished bool
+	count    int64
+}
+
+// the generator is kept in the env of the body as `yield`, which is a keyword,
+// so scripts can not read or shadow it, like `this`
+func (g *generator) Inspect() string {
+	return "generator " + g.fn.Name
+}
+
+func (g *generator) Type() object.ObjectType {
+	return object.OBJ_ITERATOR
+}
+
+// newGenerator returns the iterator of the generator function fn called with env, which has the arguments
+func newGenerator(fn *object.Function, env *object.Environment) *object.Iterator {
+	g := &generator{
+		fn:     fn,
+		env:    env,
+		resume: make(chan struct{}),
+		steps:  make(chan generatorStep),
+		stop:   make(chan struct{}),
+		live:   interpreterOf(env).generators,
+	}
+	env.Set(tokens.KWYield, g)
+	g.live.add(g)
+
+	it := &object.Iterator{Next: g.next, Close: g.close, Name: g.Inspect()}
+	// the goroutine of the body only refers to g, so the iterator can be collected when the script drops it
+	runtime.SetFinalizer(it, func(it *object.Iterator) { it.Close() })
+	return it
+}
+
+func (g *generator) next() (object.Object, object.Object, bool, error) {
+	if g.finished {
+		return nil, nil, true, nil
+	}
+
+	select {
+	case <-g.stop:
+		g.finished = true
+		return nil, nil, true, nil
+	default:
+	}
+
+	if !g.started {
+		g.started = true
+		g.live.running.Add(1)
+		go g.run()
+	} else {
+		g.resume <- struct{}{}
+	}
+
+	step := <-g.steps
+	if step.err != nil || step.done {
+		g.finished = true
+		g.close()
+		return nil, step.value, step.done, step.err
+	}
+
+	key := &object.Integer{Value: g.count}
+	g.count++
+	return key, step.value, false, nil
+}
+
+// close stops the generator, the body which is suspended at yield returns without running the rest of it
+func (g *generator) close() {
+	g.stopped.Do(func() {
+		close(g.stop)
+		g.live.remove(g)
+	})
+}
+
+// generatorSet is the generators of an interpreter which are not closed yet,
+// it is locked since the finalizer closes the generators in its own goroutine
+type generatorSet struct {
+	mu   sync.Mutex
+	gens map[*generator]struct{}
+	// running counts the goroutines of the bodies which have not exited
+	running sync.WaitGroup
+}
+
+func newGeneratorSet() *generatorSet {
+	return &generatorSet{gens: make(map[*generator]struct{})}
+}
+
+func (s *generatorSet) add(g *generator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gens[g] = struct{}{}
+}
+
+func (s *generatorSet) remove(g *generator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.gens, g)
+}
+
+// closeAll closes all the generators, and waits until the goroutines of them exit
+func (s *generatorSet) closeAll() {
+	s.mu.Lock()
+	gens := make([]*generator, 0, len(s.gens))
+	for g := range s.gens {
+		gens = append(gens, g)
+	}
+	s.mu.Unlock()
+
+	for _, g := range gens {
+		g.close()
+	}
+	s.running.Wait()
+}
+
+func (g *generator) run() {
+	defer g.live.running.Done()
+
+	result, err := Eval(g.fn.Body, g.env)
+	if errors.Is(err, errGeneratorClosed) {
+		return
+	}
+
+	if ret, ok := result.(*object.Return); ok {
+		result = ret.Value
+	}
+	if result == nil || err != nil {
+		result = &object.Null{}
+	}
+
+	select {
+	case g.steps <- generatorStep{value: result, done: true, err: err}:
+	case <-g.stop:
+	}
+}
+
+// yield hands value to the caller of next(), and waits until the next next()
+func (g *generator) yield(value object.Object) error {
+	select {
+	case g.steps <- generatorStep{value: value}:
+	case <-g.stop:
+		return errGeneratorClosed
+	}
+
+	select {
+	case <-g.resume:
+		return nil
+	case <-g.stop:
+		return errGeneratorClosed
+	}
+}
+
+func evalYield(v *ast.YieldStmt, env *object.Environment) (object.Object, error) {
+	obj, ok := env.Get(tokens.KWYield)
+	if !ok {
+		return nil, fmt.Errorf("yield outside a generator")
+	}
+
+	value, err := Eval(v.Value, env)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		value = &object.Null{}
+	}
+
+	if err := obj.(*generator).yield(value); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
@@ -0,0 +1,284 @@
+package eval
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/lexer"
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/parser"
+)
+
+const naturalsGen = `
+fn naturals() {
+	let n = 0;
+	while (true) {
+		yield n;
+		n += 1;
+	}
+}
+`
+
+func TestEvalGenerator(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "for-in over generator",
+			input: `
+			fn countdown(n) {
+				while (n > 0) {
+					yield n;
+					n -= 1;
+				}
+			}
+			let out = [];
+			for (i, x in countdown(3)) { out.push(i * 10 + x); }
+			return out;
+			`,
+			want: intSlice(3, 12, 21),
+		},
+		{
+			name: "body runs lazily",
+			input: `
+			let log = [];
+			fn gen() {
+				log.push(1);
+				yield "a";
+				log.push(2);
+				yield "b";
+				log.push(3);
+			}
+			let g = gen();
+			let before = len(log);
+			g.next();
+			return [before, len(log)];
+			`,
+			want: intSlice(0, 1),
+		},
+		{
+			name: "return ends the generator",
+			input: `
+			fn gen() {
+				yield 1;
+				return 2;
+				yield 3;
+			}
+			let g = gen();
+			return [g.next(), g.next(), g.next(), collect(gen())];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				nextResultMap(false, &object.Integer{Value: 1}),
+				nextResultMap(true, &object.Integer{Value: 2}),
+				nextResultMap(true, &object.Null{}),
+				intSlice(1),
+			}},
+		},
+		{
+			name: "yield without value",
+			input: `
+			fn gen() { yield; }
+			return collect(gen());
+			`,
+			want: &object.Slice{Elements: []object.Object{&object.Null{}}},
+		},
+		{
+			name: "infinite generator with early return",
+			input: naturalsGen + `
+			fn firstSquareOver(limit) {
+				for (n in naturals()) {
+					if (n * n > limit) { return n; }
+				}
+			}
+			return firstSquareOver(50);
+			`,
+			want: &object.Integer{Value: 8},
+		},
+		{
+			name: "generators compose",
+			input: naturalsGen + `
+			fn take(it, count) {
+				for (x in it) {
+					if (count == 0) { return; }
+					count -= 1;
+					yield x;
+				}
+			}
+			fn evens(it) {
+				for (x in it) {
+					if (x % 2 == 0) { yield x; }
+				}
+			}
+			return collect(take(evens(naturals()), 4));
+			`,
+			want: intSlice(0, 2, 4, 6),
+		},
+		{
+			name: "recursive generator",
+			input: `
+			fn upto(n) {
+				if (n > 0) {
+					for (x in upto(n - 1)) { yield x; }
+					yield n;
+				}
+			}
+			return collect(upto(5));
+			`,
+			want: intSlice(1, 2, 3, 4, 5),
+		},
+		{
+			name: "generator method",
+			input: `
+			class Range {
+				iter() {
+					let i = this.low;
+					while (i < this.high) {
+						yield i;
+						i += 1;
+					}
+				}
+			}
+			let r = Range();
+			r.low = 2;
+			r.high = 5;
+			return [collect(r), collect(r)];
+			`,
+			want: &object.Slice{Elements: []object.Object{intSlice(2, 3, 4), intSlice(2, 3, 4)}},
+		},
+		{
+			name: "close stops the generator",
+			input: `
+			let log = [];
+			fn gen() {
+				yield 1;
+				log.push("resumed");
+				yield 2;
+			}
+			let g = gen();
+			g.next();
+			g.close();
+			return [g.next(), log];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				nextResultMap(true, &object.Null{}),
+				&object.Slice{Elements: []object.Object{}},
+			}},
+		},
+		{
+			name: "error in generator",
+			input: `
+			fn gen() {
+				yield 1;
+				yield missing;
+			}
+			let g = gen();
+			g.next();
+			g.next();
+			`,
+			want: &object.Error{Message: "identifier: missing is not found"},
+		},
+		{
+			name: "generator is advanced in its loop",
+			input: naturalsGen + `
+			let g = naturals();
+			for (x in g) {
+				if (x == 2) { return [x, g.next()]; }
+			}
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.Integer{Value: 2},
+				nextResultMap(false, &object.Integer{Value: 3}),
+			}},
+		},
+		{
+			name: "leaving the loop closes the generator",
+			input: naturalsGen + `
+			let g = naturals();
+			fn first() {
+				for (x in g) {
+					if (x == 2) { return x; }
+				}
+			}
+			return [first(), g.next()];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.Integer{Value: 2},
+				nextResultMap(true, &object.Null{}),
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestEvalGeneratorsExitWithClose(t *testing.T) {
+	// the first run starts the runtime goroutines, like the one of the finalizers
+	_, err := testEvalInput(naturalsGen + `let g = naturals(); g.next();`)
+	assert.Nil(t, err)
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 20; i++ {
+		obj, err := testEvalInput(naturalsGen + `
+		let g = naturals();
+		let h = naturals();
+		g.next();
+		h.next();
+		for (x in naturals()) {
+			if (x == 2) { return g.next(); }
+		}
+		`)
+		assert.Nil(t, err)
+		assert.Equal(t, nextResultMap(false, &object.Integer{Value: 1}), obj)
+	}
+	assert.Equal(t, before, runtime.NumGoroutine())
+}
+
+func TestEvalGeneratorAcrossPrograms(t *testing.T) {
+	// the first run starts the runtime goroutines, like the one of the finalizers
+	_, err := testEvalInput(naturalsGen + `let g = naturals(); g.next();`)
+	assert.Nil(t, err)
+	before := runtime.NumGoroutine()
+
+	env := NewEnvironment(nil)
+	for _, input := range []string{naturalsGen + `let g = naturals(); g.next();`, `g.next();`} {
+		obj, err := testEvalProgram(input, env)
+		assert.Nil(t, err)
+		assert.IsType(t, &object.Map{}, obj)
+	}
+
+	obj, err := testEvalProgram(`return g.next();`, env)
+	assert.Nil(t, err)
+	assert.Equal(t, nextResultMap(false, &object.Integer{Value: 2}), obj)
+	assert.Equal(t, before+1, runtime.NumGoroutine())
+
+	Close(env)
+	assert.Equal(t, before, runtime.NumGoroutine())
+
+	obj, err = testEvalProgram(`return g.next();`, env)
+	assert.Nil(t, err)
+	assert.Equal(t, nextResultMap(true, &object.Null{}), obj)
+}
+
+// testEvalProgram runs input in env, like a line of the REPL
+func testEvalProgram(input string, env *object.Environment) (object.Object, error) {
+	tokens, err := lexer.TokensFromInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := parser.NewParser(tokens).ParseProgram()
+	if err != nil {
+		return nil, err
+	}
+	return Eval(root, env)
+}
@@ -0,0 +1,271 @@
+package eval
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/lexer"
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/parser"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+const (
+	// FileVar is bound as a constant to the path of the script in its global env
+	FileVar = "__file__"
+)
+
+var (
+	ErrModuleNotFound   = "module not found: %s"
+	ErrImportNotAllowed = "import of %s is not allowed"
+	ErrImportCycle      = "import cycle: %s"
+	ErrInvalidModuleVar = "can not use %s as module name, use `import ... as name`"
+)
+
+// scriptState is the settings of the global env of a script file,
+// the imports in the script are resolved relative to its file
+type scriptState struct {
+	*interpreter
+	file string
+}
+
+// newScriptEnvironment creates the global env of file, which shares the interpreter it
+func newScriptEnvironment(file string, it *interpreter) *object.Environment {
+	env := object.NewEnvironmentWithSettings(&scriptState{interpreter: it, file: file})
+	env.Define(FileVar, &object.String{Value: file}, true)
+	return env
+}
+
+// moduleLoader loads every module file once, and detects import cycles
+type moduleLoader struct {
+	cache   map[string]*object.Module
+	loading []string // the files being loaded, the last one is the innermost
+}
+
+func newModuleLoader() *moduleLoader {
+	return &moduleLoader{cache: make(map[string]*object.Module)}
+}
+
+// NewScriptEnvironment creates the global env to run the script file
+func NewScriptEnvironment(file string, opts *Options) *object.Environment {
+	if abs, err := filepath.Abs(file); err == nil {
+		file = abs
+	}
+	return newScriptEnvironment(file, newInterpreter(opts))
+}
+
+func evalImportStmt(stmt *ast.ImportStmt, env *object.Environment) (object.Object, error) {
+	path := stmt.Path.Literal
+	mod, err := importModule(path, env)
+	if err != nil {
+		return nil, err
+	}
+
+	// from "lib.si" import add, sub;
+	// the imported names are constants, like the module variable
+	if stmt.Names != nil {
+		for _, name := range stmt.Names {
+			member, err := mod.Get(name)
+			if err != nil {
+				return nil, err
+			}
+			if err := env.Define(name.Literal, member, true); err != nil {
+				return nil, err
+			}
+		}
+		return mod, nil
+	}
+
+	name := moduleVarName(stmt)
+	if stmt.Alias == nil && !isIdentifier(name) {
+		return nil, fmt.Errorf(ErrInvalidModuleVar, name)
+	}
+
+	if err := env.Define(name, mod, true); err != nil {
+		return nil, err
+	}
+	return mod, nil
+}
+
+// moduleVarName returns the name which `import "path" as name;` binds, the file name without extension if there is no alias
+func moduleVarName(stmt *ast.ImportStmt) string {
+	if stmt.Alias != nil {
+		return stmt.Alias.Literal
+	}
+	path := stmt.Path.Literal
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+func importModule(path string, env *object.Environment) (*object.Module, error) {
+	it := interpreterOf(env)
+
+	file, err := resolveModule(path, importingFile(env), it.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return it.loader.load(file, it)
+}
+
+// importingFile returns the file of the script which runs the import, empty if it is unknown
+func importingFile(env *object.Environment) string {
+	if s, ok := env.Settings().(*scriptState); ok {
+		return s.file
+	}
+	return ""
+}
+
+// resolveModule looks up path relative to the importing file at first, then in the search path,
+// only the files inside the import roots, the search path or the fs roots can be imported
+func resolveModule(path, from string, opts *Options) (string, error) {
+	var candidates []string
+	if filepath.IsAbs(path) {
+		candidates = append(candidates, path)
+	} else {
+		dir := "."
+		if from != "" {
+			dir = filepath.Dir(from)
+		}
+		candidates = append(candidates, filepath.Join(dir, path))
+		for _, sp := range opts.SearchPath {
+			candidates = append(candidates, filepath.Join(sp, path))
+		}
+	}
+
+	caps := &opts.Capabilities
+	roots := make([]string, 0, len(caps.ImportRoots)+len(opts.SearchPath)+len(caps.FSRoots))
+	roots = append(append(append(roots, caps.ImportRoots...), opts.SearchPath...), caps.FSRoots...)
+
+	denied := false
+	for _, c := range candidates {
+		abs, err := filepath.Abs(c)
+		if err != nil {
+			continue
+		}
+		real, err := realPath(abs)
+		if err != nil {
+			continue
+		}
+		if !inRoots(roots, real) {
+			denied = true
+			continue
+		}
+
+		info, err := os.Stat(real)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		return real, nil
+	}
+
+	if denied {
+		return "", fmt.Errorf(ErrImportNotAllowed, path)
+	}
+	return "", fmt.Errorf(ErrModuleNotFound, path)
+}
+
+func (l *moduleLoader) load(file string, it *interpreter) (*object.Module, error) {
+	if mod, ok := l.cache[file]; ok {
+		return mod, nil
+	}
+
+	for idx, f := range l.loading {
+		if f == file {
+			chain := make([]string, 0, len(l.loading)-idx+1)
+			for _, f := range append(l.loading[idx:], file) {
+				chain = append(chain, filepath.Base(f))
+			}
+			return nil, fmt.Errorf(ErrImportCycle, strings.Join(chain, " -> "))
+		}
+	}
+
+	l.loading = append(l.loading, file)
+	defer func() { l.loading = l.loading[:len(l.loading)-1] }()
+
+	mod, err := evalModule(file, it)
+	if err != nil {
+		return nil, fmt.Errorf("import %s: %w", filepath.Base(file), err)
+	}
+
+	l.cache[file] = mod
+	return mod, nil
+}
+
+// evalModule runs the module file in its own global env
+func evalModule(file string, it *interpreter) (*object.Module, error) {
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	tks, err := lexer.TokensFromInput(string(bs))
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := parser.NewParser(tks).ParseProgram()
+	if err != nil {
+		return nil, err
+	}
+
+	env := newScriptEnvironment(file, it)
+
+	result, err := Eval(program, env)
+	if err != nil {
+		return nil, err
+	}
+	if errObj, ok := result.(*object.Error); ok {
+		return nil, fmt.Errorf("%s", errObj.Message)
+	}
+
+	members := make(map[string]object.Object)
+	for _, name := range exportedNames(program) {
+		if v, ok := env.Get(name); ok {
+			members[name] = v
+		}
+	}
+
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	return object.NewModule(name, members), nil
+}
+
+// exportedNames returns the names declared by export statements,
+// or all the names declared at the top level if the module exports nothing
+func exportedNames(program *ast.Program) []string {
+	var exported, topLevel []string
+	for _, stmt := range program.Stmts {
+		if ex, ok := stmt.(*ast.ExportStmt); ok {
+			exported = append(exported, declaredName(ex.Stmt))
+			continue
+		}
+
+		if name := declaredName(stmt); name != "" {
+			topLevel = append(topLevel, name)
+		}
+	}
+
+	if len(exported) > 0 {
+		return exported
+	}
+	return topLevel
+}
+
+func declaredName(stmt ast.Stmt) string {
+	switch v := stmt.(type) {
+	case *ast.LetStmt:
+		return v.Ident.Name
+	case *ast.Function:
+		return v.Name.Literal
+	case *ast.ClassStmt:
+		return v.NameIdent.Name
+	}
+	return ""
+}
+
+func isIdentifier(name string) bool {
+	tks, err := lexer.TokensFromInput(name)
+	return err == nil && len(tks) == 2 && tks[0].TkType == tokens.IDENT
+}
@@ -0,0 +1,310 @@
+package eval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/lexer"
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/parser"
+)
+
+func testEvalScript(file string, opts *Options) (object.Object, error) {
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := lexer.TokensFromInput(string(bs))
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := parser.NewParser(tokens).ParseProgram()
+	if err != nil {
+		return nil, err
+	}
+
+	env := NewScriptEnvironment(file, opts)
+	defer Close(env)
+	return Eval(root, env)
+}
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		searchPath []string
+		main       string
+		want       object.Object
+	}{
+		{
+			name: "import as",
+			files: map[string]string{
+				"lib.si": `
+				let base = 10;
+				fn add(a, b) { return a + b; }
+				`,
+			},
+			main: `
+			import "lib.si" as lib;
+			return lib.add(1, lib.base);
+			`,
+			want: &object.Integer{Value: 11},
+		},
+		{
+			name: "import without alias",
+			files: map[string]string{
+				"lib.si": `let base = 10;`,
+			},
+			main: `
+			import "lib.si";
+			return lib.base;
+			`,
+			want: &object.Integer{Value: 10},
+		},
+		{
+			name: "from import",
+			files: map[string]string{
+				"lib.si": `
+				fn add(a, b) { return a + b; }
+				fn sub(a, b) { return a - b; }
+				`,
+			},
+			main: `
+			from "lib.si" import add, sub;
+			return sub(add(1, 2), 10);
+			`,
+			want: &object.Integer{Value: -7},
+		},
+		{
+			name: "exported function uses private name",
+			files: map[string]string{
+				"lib.si": `
+				fn helper() { return 1; }
+				export fn pub() { return helper() + 1; }
+				`,
+			},
+			main: `
+			from "lib.si" import pub;
+			return pub();
+			`,
+			want: &object.Integer{Value: 2},
+		},
+		{
+			name: "private name is not visible",
+			files: map[string]string{
+				"lib.si": `
+				fn helper() { return 1; }
+				export fn pub() { return helper() + 1; }
+				`,
+			},
+			main: `
+			import "lib.si" as lib;
+			return lib.helper();
+			`,
+			want: &object.Error{Message: "module lib has no member: helper"},
+		},
+		{
+			name: "module is evaluated once",
+			files: map[string]string{
+				"lib.si": `let xs = [];`,
+			},
+			main: `
+			import "lib.si" as a;
+			import "lib.si" as b;
+			a.xs.push(1);
+			return len(b.xs);
+			`,
+			want: &object.Integer{Value: 1},
+		},
+		{
+			name: "path is relative to the importing file",
+			files: map[string]string{
+				"pkg/a.si": `
+				import "b.si" as b;
+				let value = b.value + 1;
+				`,
+				"pkg/b.si": `let value = 1;`,
+			},
+			main: `
+			import "pkg/a.si" as a;
+			return a.value;
+			`,
+			want: &object.Integer{Value: 2},
+		},
+		{
+			name: "module variable is a constant",
+			files: map[string]string{
+				"lib.si": `let value = 1;`,
+			},
+			main: `
+			import "lib.si";
+			lib = 1;
+			`,
+			want: &object.Error{Message: "can not assign to constant: lib"},
+		},
+		{
+			name: "imported name is a constant",
+			files: map[string]string{
+				"lib.si": `fn add(a, b) { return a + b; }`,
+			},
+			main: `
+			from "lib.si" import add;
+			fn f() { add = 1; }
+			`,
+			want: &object.Error{Message: "can not assign to constant: add"},
+		},
+		{
+			name: "imported name can not be declared again",
+			files: map[string]string{
+				"lib.si": `fn add(a, b) { return a + b; }`,
+			},
+			main: `
+			from "lib.si" import add;
+			let add = 1;
+			`,
+			want: &object.Error{Message: "identifier: add is already declared"},
+		},
+		{
+			name: "__file__ is a constant",
+			files: map[string]string{
+				"lib.si": `let value = 1;`,
+			},
+			main: `
+			__file__ = "/etc/x";
+			`,
+			want: &object.Error{Message: "can not assign to constant: __file__"},
+		},
+		{
+			name: "shadowed __file__ does not change the import path",
+			files: map[string]string{
+				"lib.si": `let value = 1;`,
+			},
+			main: `
+			fn load() {
+				let __file__ = "/etc/x";
+				import "lib.si" as lib;
+				return lib.value;
+			}
+			return load();
+			`,
+			want: &object.Integer{Value: 1},
+		},
+		{
+			name: "search path",
+			files: map[string]string{
+				"libs/util.si": `let value = 42;`,
+			},
+			searchPath: []string{"libs"},
+			main: `
+			import "util.si" as util;
+			return util.value;
+			`,
+			want: &object.Integer{Value: 42},
+		},
+		{
+			name: "import cycle",
+			files: map[string]string{
+				"a.si": `import "b.si" as b;`,
+				"b.si": `import "a.si" as a;`,
+			},
+			main: `
+			import "a.si" as a;
+			`,
+			want: &object.Error{Message: "import a.si: import b.si: import cycle: a.si -> b.si -> a.si"},
+		},
+		{
+			name: "module not found",
+			main: `
+			import "missing.si" as m;
+			`,
+			want: &object.Error{Message: "module not found: missing.si"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				assert.Nil(t, os.WriteFile(path, []byte(content), 0o644))
+			}
+
+			mainFile := filepath.Join(dir, "main.si")
+			assert.Nil(t, os.WriteFile(mainFile, []byte(tt.main), 0o644))
+
+			var searchPath []string
+			for _, sp := range tt.searchPath {
+				searchPath = append(searchPath, filepath.Join(dir, sp))
+			}
+
+			opts := &Options{SearchPath: searchPath, Capabilities: Capabilities{ImportRoots: []string{dir}}}
+			obj, err := testEvalScript(mainFile, opts)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestImportSandbox(t *testing.T) {
+	outside := t.TempDir()
+	secret := filepath.Join(outside, "secret.si")
+	assert.Nil(t, os.WriteFile(secret, []byte(`let value = 1;`), 0o644))
+
+	dir := t.TempDir()
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "app"), 0o755))
+	assert.Nil(t, os.Symlink(outside, filepath.Join(dir, "app", "link")))
+
+	tests := []struct {
+		name  string
+		input string
+		caps  Capabilities
+		want  object.Object
+	}{
+		{
+			name:  "no import roots",
+			input: `import "` + secret + `" as m;`,
+			want:  &object.Error{Message: "import of " + secret + " is not allowed"},
+		},
+		{
+			name:  "absolute path outside the import roots",
+			input: `import "` + secret + `" as m;`,
+			caps:  Capabilities{ImportRoots: []string{filepath.Join(dir, "app")}},
+			want:  &object.Error{Message: "import of " + secret + " is not allowed"},
+		},
+		{
+			name:  "relative path escapes the import roots",
+			input: `import "../../` + filepath.Base(outside) + `/secret.si" as m;`,
+			caps:  Capabilities{ImportRoots: []string{filepath.Join(dir, "app")}},
+			want:  &object.Error{Message: "import of ../../" + filepath.Base(outside) + "/secret.si is not allowed"},
+		},
+		{
+			name:  "symlink escapes the import roots",
+			input: `import "link/secret.si" as m;`,
+			caps:  Capabilities{ImportRoots: []string{filepath.Join(dir, "app")}},
+			want:  &object.Error{Message: "import of link/secret.si is not allowed"},
+		},
+		{
+			name:  "fs roots are importable",
+			input: `import "` + secret + `" as m; return m.value;`,
+			caps:  Capabilities{FSRoots: []string{outside}},
+			want:  &object.Integer{Value: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mainFile := filepath.Join(dir, "app", "main.si")
+			assert.Nil(t, os.WriteFile(mainFile, []byte(tt.input), 0o644))
+
+			obj, err := testEvalScript(mainFile, &Options{Capabilities: tt.caps})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func TestEvalStrAndRepr(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "scalars",
+			input: `return str(1) + " " + str(1.5) + " " + str(2.0) + " " + str(true) + " " + str(null) + " " + str("a");`,
+			want:  `1 1.5 2.0 true null a`,
+		},
+		{
+			name:  "repr quotes strings",
+			input: `return repr("say \"hi\"\n");`,
+			want:  `"say \"hi\"\n"`,
+		},
+		{
+			name:  "slice",
+			input: `return str([1, "a", true, null, [2.5]]);`,
+			want:  `[1, "a", true, null, [2.5]]`,
+		},
+		{
+			name:  "map keeps insertion order",
+			input: `return str({"b": 1, "a": [1], 3: {}});`,
+			want:  `{"b": 1, "a": [1], 3: {}}`,
+		},
+		{
+			name: "instance fields are sorted",
+			input: `
+			class Point {}
+			let p = Point();
+			p.y = 2;
+			p.x = 1;
+			p.label = "origin";
+			return str(p);
+			`,
+			want: `Point{label: "origin", x: 1, y: 2}`,
+		},
+		{
+			name: "functions",
+			input: `
+			fn add(a, b) { return a + b; }
+			class C { m(x) {} }
+			return str([add, C, C().m, len, math]);
+			`,
+			want: `[fn add(a, b), C, fn m(x), builtin fn len, module math]`,
+		},
+		{
+			name: "self referencing slice",
+			input: `
+			let xs = [1];
+			xs.push(xs);
+			return str(xs);
+			`,
+			want: `[1, [...]]`,
+		},
+		{
+			name: "self referencing instance",
+			input: `
+			class Node {}
+			let n = Node();
+			n.next = n;
+			n.values = [n];
+			return str(n);
+			`,
+			want: `Node{next: Node{...}, values: [Node{...}]}`,
+		},
+		{
+			name: "shared values are not cycles",
+			input: `
+			let a = [1];
+			return str([a, a]);
+			`,
+			want: `[[1], [1]]`,
+		},
+		{
+			name: "str calls __str__",
+			input: `
+			class Money {
+				__str__() { return "$" + str(this.cents / 100); }
+			}
+			let m = Money();
+			m.cents = 250;
+			return str(m);
+			`,
+			want: `$2`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, &object.String{Value: tt.want}, obj)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package eval
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+// maxPowResultBits limits the size of the result of big integer pow, about 1.2 million decimal digits,
+// so 2 ** 10000000000 fails at once instead of running out of memory
+const maxPowResultBits = 1 << 22
+
+var operatorSymbols = map[tokens.TokenType]string{
+	tokens.PLUS:     "+",
+	tokens.MINUS:    "-",
+	tokens.ASTERISK: "*",
+	tokens.SLASH:    "/",
+	tokens.PERCENT:  "%",
+	tokens.POW:      "**",
+}
+
+// IntegerOverflowError is returned when the integer arithmetic overflows int64
+// and the interpreter is not allowed to promote the result to BigInt
+type IntegerOverflowError struct {
+	Expr string
+}
+
+func (e *IntegerOverflowError) Error() string {
+	return "integer overflow: " + e.Expr
+}
+
+func newOverflowError(left int64, op tokens.TokenType, right int64) error {
+	return &IntegerOverflowError{Expr: fmt.Sprintf("%d %s %d", left, operatorSymbols[op], right)}
+}
+
+func addInt(a, b int64) (int64, bool) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, false
+	}
+	return a + b, true
+}
+
+func subInt(a, b int64) (int64, bool) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, false
+	}
+	return a - b, true
+}
+
+func divInt(a, b int64) (int64, bool) {
+	if a == math.MinInt64 && b == -1 {
+		return 0, false
+	}
+	return a / b, true
+}
+
+// powInt calculates base ** exp by squaring, ok is false when the result overflows int64
+func powInt(base, exp int64) (int64, bool) {
+	var result int64 = 1
+	var ok bool
+	for exp > 0 {
+		if exp&1 == 1 {
+			if result, ok = mulInt(result, base); !ok {
+				return 0, false
+			}
+		}
+
+		exp >>= 1
+		if exp > 0 {
+			if base, ok = mulInt(base, base); !ok {
+				return 0, false
+			}
+		}
+	}
+
+	return result, true
+}
+
+func mulInt(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return c, true
+}
+
+func toBigInt(obj object.Object) (*big.Int, bool) {
+	switch v := obj.(type) {
+	case *object.Integer:
+		return big.NewInt(v.Value), true
+	case *object.BigInt:
+		return v.Value, true
+	}
+	return nil, false
+}
+
+// normalizeBigInt demotes the value to object.Integer when it fits in int64
+func normalizeBigInt(v *big.Int) object.Object {
+	if v.IsInt64() {
+		return &object.Integer{Value: v.Int64()}
+	}
+	return &object.BigInt{Value: v}
+}
+
+func doBigMath(obj1, obj2 object.Object, op tokens.TokenType) (object.Object, error) {
+	left, ok := toBigInt(obj1)
+	if !ok {
+		return nil, fmt.Errorf("%v must be integer", obj1.Inspect())
+	}
+	right, ok := toBigInt(obj2)
+	if !ok {
+		return nil, fmt.Errorf("%v must be integer", obj2.Inspect())
+	}
+
+	result := new(big.Int)
+	switch op {
+	case tokens.PLUS:
+		result.Add(left, right)
+	case tokens.MINUS:
+		result.Sub(left, right)
+	case tokens.ASTERISK:
+		result.Mul(left, right)
+	case tokens.SLASH:
+		if right.Sign() == 0 {
+			return nil, fmt.Errorf(ErrDivideByZero)
+		}
+		result.Quo(left, right)
+	case tokens.PERCENT:
+		if right.Sign() == 0 {
+			return nil, fmt.Errorf(ErrDivideByZero)
+		}
+		result.Rem(left, right)
+	case tokens.POW:
+		if right.Sign() < 0 {
+			l, _ := toFloat(obj1)
+			r, _ := toFloat(obj2)
+			return &object.Float{Value: math.Pow(l, r)}, nil
+		}
+		if !powFits(left, right) {
+			return nil, fmt.Errorf("exponent is too large: %s", right.String())
+		}
+		result.Exp(left, right, nil)
+	default:
+		return nil, fmt.Errorf("unsupported operator: %s", op.String())
+	}
+
+	return normalizeBigInt(result), nil
+}
+
+// powFits reports whether base ** exp has at most maxPowResultBits bits,
+// the result has at most exp * base.BitLen() bits, and 0, 1 and -1 stay small with any exponent
+func powFits(base, exp *big.Int) bool {
+	if base.CmpAbs(big.NewInt(1)) <= 0 {
+		return true
+	}
+	return exp.IsInt64() && exp.Int64() <= maxPowResultBits/int64(base.BitLen())
+}
+
+func compareBigInt(left, right *big.Int, op tokens.TokenType) bool {
+	c := left.Cmp(right)
+	switch op {
+	case tokens.GT:
+		return c > 0
+	case tokens.GTEQ:
+		return c >= 0
+	case tokens.LT:
+		return c < 0
+	case tokens.LTEQ:
+		return c <= 0
+	case tokens.NOTEQUAL:
+		return c != 0
+	case tokens.EQUAL:
+		return c == 0
+	}
+
+	return false
+}
@@ -0,0 +1,169 @@
+package eval
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/lexer"
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/parser"
+)
+
+func testEvalWithOptions(input string, opts *Options) (object.Object, error) {
+	tokens, err := lexer.TokensFromInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := parser.NewParser(tokens).ParseProgram()
+	if err != nil {
+		return nil, err
+	}
+
+	env := NewEnvironment(opts)
+	defer Close(env)
+	return Eval(root, env)
+}
+
+func bigInt(s string) *object.BigInt {
+	v, _ := new(big.Int).SetString(s, 10)
+	return &object.BigInt{Value: v}
+}
+
+func TestIntegerOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		overflow OverflowMode
+		want     object.Object
+	}{
+		{
+			name:  "plus overflow",
+			input: `return 9223372036854775807 + 1;`,
+			want:  &object.Error{Message: "integer overflow: 9223372036854775807 + 1"},
+		},
+		{
+			name:  "minus overflow",
+			input: `return -9223372036854775807 - 2;`,
+			want:  &object.Error{Message: "integer overflow: -9223372036854775807 - 2"},
+		},
+		{
+			name:  "multiply overflow",
+			input: `return 4294967296 * 4294967296;`,
+			want:  &object.Error{Message: "integer overflow: 4294967296 * 4294967296"},
+		},
+		{
+			name:  "pow overflow",
+			input: `return 2 ** 70;`,
+			want:  &object.Error{Message: "integer overflow: 2 ** 70"},
+		},
+		{
+			name:  "no overflow",
+			input: `return 9223372036854775806 + 1;`,
+			want:  &object.Integer{Value: 9223372036854775807},
+		},
+		{
+			name:     "promote pow to big int",
+			input:    `return 2 ** 70;`,
+			overflow: OverflowBigInt,
+			want:     bigInt("1180591620717411303424"),
+		},
+		{
+			name:     "promote multiply to big int",
+			input:    `return 4294967296 * 4294967296 * 10;`,
+			overflow: OverflowBigInt,
+			want:     bigInt("184467440737095516160"),
+		},
+		{
+			name:     "big int is demoted when it fits int64",
+			input:    `return 2 ** 70 / 2 ** 60 - 1;`,
+			overflow: OverflowBigInt,
+			want:     &object.Integer{Value: 1023},
+		},
+		{
+			name:     "compare big int",
+			input:    `return 2 ** 70 > 9223372036854775807;`,
+			overflow: OverflowBigInt,
+			want:     &object.Bool{Value: true},
+		},
+		{
+			name:     "big int modulo and negate",
+			input:    `return -(2 ** 64) % 1000;`,
+			overflow: OverflowBigInt,
+			want:     &object.Integer{Value: -616},
+		},
+		{
+			name:     "big int with float",
+			input:    `return 2 ** 64 * 0.5;`,
+			overflow: OverflowBigInt,
+			want:     &object.Float{Value: 9223372036854775808},
+		},
+		{
+			name:     "big pow below the result limit",
+			input:    `return [2 ** 2000000 > 0, 1 ** 10000000000, (-1) ** 10000000001];`,
+			overflow: OverflowBigInt,
+			want: &object.Slice{Elements: []object.Object{
+				&object.Bool{Value: true},
+				&object.Integer{Value: 1},
+				&object.Integer{Value: -1},
+			}},
+		},
+		{
+			name:     "big pow above the result limit",
+			input:    `return 2 ** 3000000;`,
+			overflow: OverflowBigInt,
+			want:     &object.Error{Message: "exponent is too large: 3000000"},
+		},
+		{
+			name:     "big int divide by zero",
+			input:    `return 2 ** 64 / 0;`,
+			overflow: OverflowBigInt,
+			want:     &object.Error{Message: ErrDivideByZero},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalWithOptions(tt.input, &Options{IntOverflow: tt.overflow})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestPowInt(t *testing.T) {
+	v, ok := powInt(-2, 63)
+	assert.True(t, ok)
+	assert.Equal(t, int64(-9223372036854775808), v)
+
+	_, ok = powInt(2, 63)
+	assert.False(t, ok)
+
+	v, ok = powInt(7, 0)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), v)
+}
+
+func TestOverflowModeSet(t *testing.T) {
+	var mode OverflowMode
+	assert.Nil(t, mode.Set("bigint"))
+	assert.Equal(t, OverflowBigInt, mode)
+	assert.Equal(t, "bigint", mode.String())
+
+	assert.Nil(t, mode.Set("error"))
+	assert.Equal(t, OverflowError, mode)
+
+	assert.EqualError(t, mode.Set("wrap"), "unknown integer overflow mode: wrap, want error or bigint")
+	assert.Equal(t, OverflowError, mode)
+}
+
+func TestBigPowHugeExponentFailsFast(t *testing.T) {
+	start := time.Now()
+	obj, err := testEvalWithOptions(`return 2 ** 10000000000;`, &Options{IntOverflow: OverflowBigInt})
+	assert.Nil(t, err)
+	assert.Equal(t, &object.Error{Message: "exponent is too large: 10000000000"}, obj)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,245 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+// the iterator protocol: iterable.iter() returns an iterator,
+// and iterator.next() returns {"done": bool, "value": v}, a map or an instance which has the 2 fields
+const (
+	methodIter  = "iter"
+	methodNext  = "next"
+	methodClose = "close"
+	fieldDone   = "done"
+	fieldValue  = "value"
+
+	builtInIter    = "iter"
+	builtInCollect = "collect"
+)
+
+var (
+	ErrNotIterable   = "%s is not iterable"
+	ErrBadNextResult = "next() must return a map or an instance with done and value, got: %s"
+)
+
+func registerIteratorBuiltins() {
+	registerBuiltin(builtInIter, builtinIter)
+	registerBuiltin(builtInCollect, builtinCollect)
+}
+
+// iter(x) returns the iterator of x
+func builtinIter(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInIter, args, 1, 1); err != nil {
+		return nil, err
+	}
+	return iteratorOf(args[0])
+}
+
+// collect(x) returns a slice with all the elements produced by the iterator of x
+func builtinCollect(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount(builtInCollect, args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	elements, err := iterableArg(builtInCollect, args[0])
+	if err != nil {
+		return nil, err
+	}
+	return &object.Slice{Elements: append([]object.Object{}, elements...)}, nil
+}
+
+// iterableArg returns the elements of a slice, or all the elements produced by the iterator of arg
+func iterableArg(name string, arg object.Object) ([]object.Object, error) {
+	if sl, ok := arg.(*object.Slice); ok {
+		return sl.Elements, nil
+	}
+
+	it, err := iteratorOf(arg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	var elements []object.Object
+	for {
+		_, value, done, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return elements, nil
+		}
+		elements = append(elements, value)
+	}
+}
+
+// iteratorOf returns the iterator of obj:
+// the native iterators of slice, string and map, the iterator returned by obj.iter() of class instance,
+// or obj itself if it is an iterator.
+// the native iterators visit the elements which the collection has when the iterator is created.
+func iteratorOf(obj object.Object) (*object.Iterator, error) {
+	switch v := obj.(type) {
+	case *object.Iterator:
+		return v, nil
+	case *object.Slice:
+		return object.NewSliceIterator(append([]object.Object{}, v.Elements...)), nil
+	case *object.String:
+		runes := []rune(v.Value)
+		chars := make([]object.Object, 0, len(runes))
+		for _, r := range runes {
+			chars = append(chars, &object.String{Value: string(r)})
+		}
+		return object.NewSliceIterator(chars), nil
+	case *object.Map:
+		return mapIterator(v), nil
+	case *object.ClassInstance:
+		if method, ok := specialMethod(v, methodIter); ok {
+			it, err := applyFunction(method, nil)
+			if err != nil {
+				return nil, err
+			}
+			return scriptIterator(it)
+		}
+		return scriptIterator(v)
+	}
+
+	return nil, fmt.Errorf(ErrNotIterable, inspect(obj))
+}
+
+// mapIterator visits the keys and values of m in the insertion order
+func mapIterator(m *object.Map) *object.Iterator {
+	pairs := m.Pairs()
+	idx := 0
+	return &object.Iterator{
+		Next: func() (object.Object, object.Object, bool, error) {
+			if idx >= len(pairs) {
+				return nil, nil, true, nil
+			}
+
+			pair := pairs[idx]
+			idx++
+			return pair.Key, pair.Value, false, nil
+		},
+	}
+}
+
+// scriptIterator wraps the iterator written in script, which has the next() method
+func scriptIterator(obj object.Object) (*object.Iterator, error) {
+	if it, ok := obj.(*object.Iterator); ok {
+		return it, nil
+	}
+
+	next, ok := specialMethod(obj, methodNext)
+	if !ok {
+		return nil, fmt.Errorf(ErrNotIterable, inspect(obj))
+	}
+
+	count := int64(0)
+	finished := false
+	return &object.Iterator{
+		Next: func() (object.Object, object.Object, bool, error) {
+			if finished {
+				return nil, nil, true, nil
+			}
+
+			result, err := applyFunction(next, nil)
+			if err != nil {
+				return nil, nil, false, err
+			}
+
+			done, value, err := nextResult(result)
+			if err != nil {
+				return nil, nil, false, err
+			}
+			if done {
+				finished = true
+				return nil, nil, true, nil
+			}
+
+			key := &object.Integer{Value: count}
+			count++
+			return key, value, false, nil
+		},
+	}, nil
+}
+
+// nextResult reads done and value from the result of next(), the missing value is null
+func nextResult(result object.Object) (bool, object.Object, error) {
+	var done, value object.Object
+	switch v := result.(type) {
+	case *object.Map:
+		var err error
+		if done, _, err = v.Get(&object.String{Value: fieldDone}); err != nil {
+			return false, nil, err
+		}
+		if value, _, err = v.Get(&object.String{Value: fieldValue}); err != nil {
+			return false, nil, err
+		}
+	case *object.ClassInstance:
+		done, value = v.Fields[fieldDone], v.Fields[fieldValue]
+	default:
+		return false, nil, fmt.Errorf(ErrBadNextResult, inspect(result))
+	}
+
+	if value == nil {
+		value = &object.Null{}
+	}
+	return isTruthy(done), value, nil
+}
+
+// bindIteratorMethod returns the methods of iterator: it.next(), it.iter() and it.close()
+func bindIteratorMethod(it *object.Iterator, name string) (*object.Builtin, bool) {
+	switch name {
+	case methodNext:
+		return &object.Builtin{
+			Name: methodNext,
+			Fn: func(args ...object.Object) (object.Object, error) {
+				if err := checkArgsCount(methodNext, args, 0, 0); err != nil {
+					return nil, err
+				}
+
+				_, value, done, err := it.Next()
+				if err != nil {
+					return nil, err
+				}
+				if value == nil {
+					value = &object.Null{}
+				}
+
+				result := object.NewMap()
+				if err := result.Set(&object.String{Value: fieldDone}, &object.Bool{Value: done}); err != nil {
+					return nil, err
+				}
+				if err := result.Set(&object.String{Value: fieldValue}, value); err != nil {
+					return nil, err
+				}
+				return result, nil
+			},
+		}, true
+	case methodIter:
+		return &object.Builtin{
+			Name: methodIter,
+			Fn: func(args ...object.Object) (object.Object, error) {
+				if err := checkArgsCount(methodIter, args, 0, 0); err != nil {
+					return nil, err
+				}
+				return it, nil
+			},
+		}, true
+	case methodClose:
+		return &object.Builtin{
+			Name: methodClose,
+			Fn: func(args ...object.Object) (object.Object, error) {
+				if err := checkArgsCount(methodClose, args, 0, 0); err != nil {
+					return nil, err
+				}
+				if it.Close != nil {
+					it.Close()
+				}
+				return &object.Null{}, nil
+			},
+		}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,178 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+const countdownClass = `
+class Countdown {
+	iter() {
+		let it = CountdownIter();
+		it.n = this.start;
+		return it;
+	}
+}
+
+class CountdownIter {
+	next() {
+		if (this.n == 0) {
+			return {"done": true};
+		}
+		this.n -= 1;
+		return {"done": false, "value": this.n + 1};
+	}
+}
+
+fn countdown(start) {
+	let c = Countdown();
+	c.start = start;
+	return c;
+}
+`
+
+func TestEvalIteratorProtocol(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "native slice iterator",
+			input: `
+			let it = [1, 2].iter();
+			return [it.next(), it.next(), it.next()];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				nextResultMap(false, &object.Integer{Value: 1}),
+				nextResultMap(false, &object.Integer{Value: 2}),
+				nextResultMap(true, &object.Null{}),
+			}},
+		},
+		{
+			name: "native string and map iterators",
+			input: `
+			return [collect(iter("ab")), collect({"a": 1, "b": 2}.iter())];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.Slice{Elements: []object.Object{&object.String{Value: "a"}, &object.String{Value: "b"}}},
+				intSlice(1, 2),
+			}},
+		},
+		{
+			name: "for-in over user iterable",
+			input: countdownClass + `
+			let out = [];
+			for (i, n in countdown(3)) {
+				out.push(i * 10 + n);
+			}
+			return out;
+			`,
+			want: intSlice(3, 12, 21),
+		},
+		{
+			name: "for-in over iterator",
+			input: `
+			let it = iter([1, 2, 3]);
+			it.next();
+			let out = [];
+			for (x in it) { out.push(x); }
+			return out;
+			`,
+			want: intSlice(2, 3),
+		},
+		{
+			name: "collection builtins consume iterables",
+			input: countdownClass + `
+			fn square(x) { return x * x; }
+			fn even(x) { return x % 2 == 0; }
+			fn add(acc, x) { return acc + x; }
+			fn small(x) { return x < 3; }
+			let c = countdown(4);
+			return [collect(c), map(c, square), filter(c, even), reduce(c, add, 0), find(c, small)];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				intSlice(4, 3, 2, 1),
+				intSlice(16, 9, 4, 1),
+				intSlice(4, 2),
+				&object.Integer{Value: 10},
+				&object.Integer{Value: 2},
+			}},
+		},
+		{
+			name: "iterator is consumed lazily",
+			input: `
+			class Naturals {
+				next() {
+					this.n += 1;
+					return {"done": false, "value": this.n};
+				}
+			}
+			fn firstOver(limit) {
+				let it = Naturals();
+				it.n = 0;
+				for (x in it) {
+					if (x > limit) { return x; }
+				}
+			}
+			return firstOver(5);
+			`,
+			want: &object.Integer{Value: 6},
+		},
+		{
+			name: "next may return an instance",
+			input: `
+			class Step {}
+			class Once {
+				next() {
+					let s = Step();
+					s.done = this.used;
+					s.value = "x";
+					this.used = true;
+					return s;
+				}
+			}
+			let o = Once();
+			o.used = false;
+			return collect(o);
+			`,
+			want: &object.Slice{Elements: []object.Object{&object.String{Value: "x"}}},
+		},
+		{
+			name: "bad next result",
+			input: `
+			class Bad {
+				next() { return 1; }
+			}
+			for (x in Bad()) {}
+			`,
+			want: &object.Error{Message: "next() must return a map or an instance with done and value, got: 1"},
+		},
+		{
+			name: "not iterable",
+			input: `
+			class Plain {}
+			return collect(Plain());
+			`,
+			want: &object.Error{Message: "collect: Plain{} is not iterable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func nextResultMap(done bool, value object.Object) *object.Map {
+	m := object.NewMap()
+	_ = m.Set(&object.String{Value: "done"}, &object.Bool{Value: done})
+	_ = m.Set(&object.String{Value: "value"}, value)
+	return m
+}
@@ -0,0 +1,282 @@
+package eval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+const (
+	moduleJSON = "json"
+)
+
+var (
+	ErrJSONCycle          = "json.stringify: cycle detected at %s"
+	ErrJSONUnsupported    = "json.stringify: unsupported value: %s"
+	ErrJSONInvalidFloat   = "json.stringify: unsupported float value: %s"
+	ErrJSONBadIndent      = "json.stringify: indent must be integer or string, got: %s"
+	ErrJSONNegativeIndent = "json.stringify: indent must not be negative, got: %d"
+	ErrJSONTrailingTokens = "json.parse: unexpected data after top-level value"
+)
+
+func newJSONModule() *object.Module {
+	return object.NewModule(moduleJSON, map[string]object.Object{
+		"parse":     &object.Builtin{Name: moduleJSON + ".parse", Fn: jsonParse},
+		"stringify": &object.Builtin{Name: moduleJSON + ".stringify", Fn: jsonStringify},
+	})
+}
+
+// json.parse(str) decodes str into maps, slices, strings, numbers, bools and null,
+// when an object has duplicate keys the last value wins
+func jsonParse(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount("json.parse", args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	str, ok := args[0].(*object.String)
+	if !ok {
+		return nil, fmt.Errorf("json.parse: %s is not a string", inspect(args[0]))
+	}
+
+	dec := json.NewDecoder(strings.NewReader(str.Value))
+	dec.UseNumber()
+
+	obj, err := decodeJSONValue(dec)
+	if err != nil {
+		return nil, fmt.Errorf("json.parse: %w", err)
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf(ErrJSONTrailingTokens)
+	}
+	return obj, nil
+}
+
+// decodeJSONValue reads the tokens of one value, so the order of object keys is kept
+func decodeJSONValue(dec *json.Decoder) (object.Object, error) {
+	tk, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := tk.(type) {
+	case json.Delim:
+		switch v {
+		case '[':
+			elements := make([]object.Object, 0)
+			for dec.More() {
+				e, err := decodeJSONValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				elements = append(elements, e)
+			}
+			if _, err := dec.Token(); err != nil { // ]
+				return nil, err
+			}
+			return &object.Slice{Elements: elements}, nil
+		case '{':
+			m := object.NewMap()
+			for dec.More() {
+				keyTk, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+
+				value, err := decodeJSONValue(dec)
+				if err != nil {
+					return nil, err
+				}
+
+				if err := m.Set(&object.String{Value: keyTk.(string)}, value); err != nil {
+					return nil, err
+				}
+			}
+			if _, err := dec.Token(); err != nil { // }
+				return nil, err
+			}
+			return m, nil
+		}
+	case string:
+		return &object.String{Value: v}, nil
+	case bool:
+		return &object.Bool{Value: v}, nil
+	case nil:
+		return &object.Null{}, nil
+	case json.Number:
+		return decodeJSONNumber(v)
+	}
+
+	return nil, fmt.Errorf("unexpected token: %v", tk)
+}
+
+// decodeJSONNumber decodes the numbers with a fraction or an exponent, like 1.0 and 1e3, as floats,
+// and the others as integers
+func decodeJSONNumber(n json.Number) (object.Object, error) {
+	if !strings.ContainsAny(n.String(), ".eE") {
+		if i, err := n.Int64(); err == nil {
+			return &object.Integer{Value: i}, nil
+		}
+
+		if b, ok := new(big.Int).SetString(n.String(), 10); ok {
+			return &object.BigInt{Value: b}, nil
+		}
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return nil, err
+	}
+	return &object.Float{Value: f}, nil
+}
+
+// json.stringify(obj, indent?) encodes obj, indent is the number of spaces or the indent string
+func jsonStringify(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount("json.stringify", args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	var indent string
+	if len(args) == 2 {
+		switch v := args[1].(type) {
+		case *object.Integer:
+			if v.Value < 0 {
+				return nil, fmt.Errorf(ErrJSONNegativeIndent, v.Value)
+			}
+			indent = strings.Repeat(" ", int(v.Value))
+		case *object.String:
+			indent = v.Value
+		default:
+			return nil, fmt.Errorf(ErrJSONBadIndent, inspect(args[1]))
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	enc := &jsonEncoder{buf: buf, visiting: make(map[object.Object]struct{})}
+	if err := enc.encode(args[0]); err != nil {
+		return nil, err
+	}
+
+	if indent == "" {
+		return &object.String{Value: buf.String()}, nil
+	}
+
+	out := &bytes.Buffer{}
+	if err := json.Indent(out, buf.Bytes(), "", indent); err != nil {
+		return nil, err
+	}
+	return &object.String{Value: out.String()}, nil
+}
+
+type jsonEncoder struct {
+	buf *bytes.Buffer
+	// visiting holds the containers on the path from the root to the current value
+	visiting map[object.Object]struct{}
+}
+
+func (enc *jsonEncoder) encode(obj object.Object) error {
+	switch v := obj.(type) {
+	case nil, *object.Null:
+		enc.buf.WriteString("null")
+	case *object.Bool:
+		enc.buf.WriteString(strconv.FormatBool(v.Value))
+	case *object.Integer:
+		enc.buf.WriteString(strconv.FormatInt(v.Value, 10))
+	case *object.BigInt:
+		enc.buf.WriteString(v.Value.String())
+	case *object.Float:
+		if math.IsNaN(v.Value) || math.IsInf(v.Value, 0) {
+			return fmt.Errorf(ErrJSONInvalidFloat, v.Inspect())
+		}
+		bs, _ := json.Marshal(v.Value)
+		enc.buf.Write(bs)
+	case *object.String:
+		enc.encodeString(v.Value)
+	case *object.Slice:
+		return enc.encodeContainer(v, func() error {
+			enc.buf.WriteByte('[')
+			for idx, e := range v.Elements {
+				if idx > 0 {
+					enc.buf.WriteByte(',')
+				}
+				if err := enc.encode(e); err != nil {
+					return err
+				}
+			}
+			enc.buf.WriteByte(']')
+			return nil
+		})
+	case *object.Map:
+		return enc.encodeContainer(v, func() error {
+			pairs := v.Pairs()
+			keys := make([]string, 0, len(pairs))
+			for _, pair := range pairs {
+				keys = append(keys, jsonKey(pair.Key))
+			}
+			return enc.encodeObject(keys, func(idx int) object.Object { return pairs[idx].Value })
+		})
+	case *object.ClassInstance:
+		return enc.encodeContainer(v, func() error {
+			keys := make([]string, 0, len(v.Fields))
+			for k := range v.Fields {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			return enc.encodeObject(keys, func(idx int) object.Object { return v.Fields[keys[idx]] })
+		})
+	default:
+		return fmt.Errorf(ErrJSONUnsupported, inspect(obj))
+	}
+
+	return nil
+}
+
+func (enc *jsonEncoder) encodeContainer(obj object.Object, fn func() error) error {
+	if _, ok := enc.visiting[obj]; ok {
+		return fmt.Errorf(ErrJSONCycle, obj.Inspect())
+	}
+
+	enc.visiting[obj] = struct{}{}
+	defer delete(enc.visiting, obj)
+	return fn()
+}
+
+func (enc *jsonEncoder) encodeObject(keys []string, valueAt func(idx int) object.Object) error {
+	enc.buf.WriteByte('{')
+	for idx, k := range keys {
+		if idx > 0 {
+			enc.buf.WriteByte(',')
+		}
+		enc.encodeString(k)
+		enc.buf.WriteByte(':')
+		if err := enc.encode(valueAt(idx)); err != nil {
+			return err
+		}
+	}
+	enc.buf.WriteByte('}')
+	return nil
+}
+
+func (enc *jsonEncoder) encodeString(s string) {
+	e := json.NewEncoder(enc.buf)
+	e.SetEscapeHTML(false)
+	_ = e.Encode(s)
+	enc.buf.Truncate(enc.buf.Len() - 1) // Encode appends a newline
+}
+
+// jsonKey converts a map key to the string used as json object key
+func jsonKey(key object.Object) string {
+	if s, ok := key.(*object.String); ok {
+		return s.Value
+	}
+	return key.Inspect()
+}
@@ -0,0 +1,173 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func TestJSONModule(t *testing.T) {
+	type args struct {
+		input string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want object.Object
+	}{
+		{
+			name: "parse nested value",
+			args: args{
+				input: `
+				let v = json.parse("{\"name\": \"x\", \"tags\": [1, 2.5, true, null], \"n\": {\"k\": 3}}");
+				return v["tags"][0] + v["n"]["k"] + len(v);
+				`,
+			},
+			want: &object.Integer{Value: 7},
+		},
+		{
+			name: "parse keeps key order",
+			args: args{
+				input: `
+				return json.parse("{\"b\": 1, \"a\": 2}").keys();
+				`,
+			},
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "b"},
+				&object.String{Value: "a"},
+			}},
+		},
+		{
+			name: "parse big integer and float",
+			args: args{
+				input: `
+				return json.parse("[18446744073709551616, 1e3, 1.0, -2E0, 3]");
+				`,
+			},
+			want: &object.Slice{Elements: []object.Object{
+				bigInt("18446744073709551616"),
+				&object.Float{Value: 1000},
+				&object.Float{Value: 1},
+				&object.Float{Value: -2},
+				&object.Integer{Value: 3},
+			}},
+		},
+		{
+			name: "parse duplicate keys",
+			args: args{
+				input: `
+				let v = json.parse("{\"a\": 1, \"b\": 2, \"a\": 3}");
+				return [v.keys(), v["a"]];
+				`,
+			},
+			want: &object.Slice{Elements: []object.Object{
+				&object.Slice{Elements: []object.Object{
+					&object.String{Value: "a"},
+					&object.String{Value: "b"},
+				}},
+				&object.Integer{Value: 3},
+			}},
+		},
+		{
+			name: "parse invalid",
+			args: args{
+				input: `
+				return json.parse("[1, 2");
+				`,
+			},
+			want: &object.Error{Message: "json.parse: unexpected end of JSON input"},
+		},
+		{
+			name: "parse trailing data",
+			args: args{
+				input: `
+				return json.parse("1 2");
+				`,
+			},
+			want: &object.Error{Message: ErrJSONTrailingTokens},
+		},
+		{
+			name: "stringify",
+			args: args{
+				input: `
+				return json.stringify({"a": [1, 2.5, "<x>"], "b": null, 1: false});
+				`,
+			},
+			want: &object.String{Value: `{"a":[1,2.5,"<x>"],"b":null,"1":false}`},
+		},
+		{
+			name: "stringify class instance with indent",
+			args: args{
+				input: `
+				class Point {}
+				let p = Point();
+				p.y = 2;
+				p.x = 1;
+				return json.stringify(p, 2);
+				`,
+			},
+			want: &object.String{Value: "{\n  \"x\": 1,\n  \"y\": 2\n}"},
+		},
+		{
+			name: "stringify cycle",
+			args: args{
+				input: `
+				let xs = [1];
+				push(xs, xs);
+				return json.stringify(xs);
+				`,
+			},
+			want: &object.Error{Message: "json.stringify: cycle detected at [1, [...]]"},
+		},
+		{
+			name: "stringify shared value is not a cycle",
+			args: args{
+				input: `
+				let xs = [1];
+				return json.stringify([xs, xs]);
+				`,
+			},
+			want: &object.String{Value: "[[1],[1]]"},
+		},
+		{
+			name: "stringify negative indent",
+			args: args{
+				input: `
+				return json.stringify([1], -1);
+				`,
+			},
+			want: &object.Error{Message: "json.stringify: indent must not be negative, got: -1"},
+		},
+		{
+			name: "stringify function",
+			args: args{
+				input: `
+				fn f() { return 1; }
+				return json.stringify({"f": f});
+				`,
+			},
+			want: &object.Error{Message: "json.stringify: unsupported value: fn f()"},
+		},
+		{
+			name: "round trip",
+			args: args{
+				input: `
+				let s = "[{\"k\":\"a\\nb\"},-1,true]";
+				return json.stringify(json.parse(s)) == s;
+				`,
+			},
+			want: &object.Bool{Value: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.args.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package eval
+
+import (
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+// evalForInStmt runs the body for each element produced by the iterator of the iterable:
+// the elements of slice, the characters of string, the keys (and values) of map, and the values of the iterators.
+// the iterable is evaluated once, and the elements added to a slice or map in the loop are not visited.
+// each iteration has its own bindings, so the closures created in the body capture the values of that iteration.
+// the iterator is closed when the loop exits, by break, return or an error too.
+func evalForInStmt(forIn *ast.ForInStmt, env *object.Environment) (object.Object, error) {
+	iterable, err := Eval(forIn.Iterable, env)
+	if err != nil {
+		return nil, err
+	}
+
+	it, err := iteratorOf(iterable)
+	if err != nil {
+		return nil, err
+	}
+	if it.Close != nil {
+		defer it.Close()
+	}
+
+	// for (k in m) visits the keys of map
+	_, keysOnly := iterable.(*object.Map)
+	keysOnly = keysOnly && forIn.Key == nil
+
+	var result object.Object
+	for {
+		key, value, done, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			break
+		}
+
+		loopEnv := object.NewBlockEnv(env)
+		switch {
+		case keysOnly:
+			loopEnv.Set(forIn.Value.Name, key)
+		case forIn.Key != nil:
+			loopEnv.Set(forIn.Key.Name, key)
+			fallthrough
+		default:
+			loopEnv.Set(forIn.Value.Name, value)
+		}
+
+		result, err = Eval(forIn.Body, loopEnv)
+		if err != nil {
+			return nil, err
+		}
+
+		if ret, isRet := result.(*object.Return); isRet {
+			return ret, nil
+		}
+		if _, isBreak := result.(*object.Break); isBreak {
+			return nil, nil
+		}
+	}
+
+	return result, nil
+}
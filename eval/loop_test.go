@@ -0,0 +1,258 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func TestEvalForIn(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "slice",
+			input: `
+			let sum = 0;
+			for (x in [1, 2, 3]) {
+				sum += x;
+			}
+			return sum;
+			`,
+			want: &object.Integer{Value: 6},
+		},
+		{
+			name: "slice with index",
+			input: `
+			let out = [];
+			for (i, x in [10, 20, 30]) {
+				out.push(i * x);
+			}
+			return out;
+			`,
+			want: intSlice(0, 20, 60),
+		},
+		{
+			name: "string by rune",
+			input: `
+			let out = [];
+			for (i, c in "héllo") {
+				if (i % 2 == 0) { out.push(c); }
+			}
+			return out;
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "h"},
+				&object.String{Value: "l"},
+				&object.String{Value: "o"},
+			}},
+		},
+		{
+			name: "map keys",
+			input: `
+			let keys = [];
+			for (k in {"a": 1, "b": 2}) {
+				keys.push(k);
+			}
+			return keys;
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "a"},
+				&object.String{Value: "b"},
+			}},
+		},
+		{
+			name: "map keys and values",
+			input: `
+			let total = 0;
+			let names = "";
+			for (k, v in {"a": 1, "b": 2}) {
+				names += k;
+				total += v;
+			}
+			return [names, total];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "ab"},
+				&object.Integer{Value: 3},
+			}},
+		},
+		{
+			name: "range",
+			input: `
+			let out = [];
+			for (i in range(10, 0, -3)) {
+				out.push(i);
+			}
+			return out;
+			`,
+			want: intSlice(10, 7, 4, 1),
+		},
+		{
+			name: "closures capture per-iteration values",
+			input: `
+			let fns = [];
+			for (x in [1, 2, 3]) {
+				fn get() { return x; }
+				fns.push(get);
+			}
+			return [fns[0](), fns[1](), fns[2]()];
+			`,
+			want: intSlice(1, 2, 3),
+		},
+		{
+			name: "return from loop",
+			input: `
+			fn find(xs, target) {
+				for (i, x in xs) {
+					if (x == target) { return i; }
+				}
+				return -1;
+			}
+			return [find([5, 6, 7], 7), find([5], 1)];
+			`,
+			want: intSlice(2, -1),
+		},
+		{
+			name: "elements added in the loop are not visited",
+			input: `
+			let xs = [1, 2];
+			for (x in xs) {
+				xs.push(x);
+			}
+			return xs;
+			`,
+			want: intSlice(1, 2, 1, 2),
+		},
+		{
+			name: "loop variables do not leak",
+			input: `
+			for (x in [1]) {}
+			return x;
+			`,
+			want: &object.Error{Message: "identifier: x is not found"},
+		},
+		{
+			name: "not iterable",
+			input: `
+			for (x in 1) {}
+			`,
+			want: &object.Error{Message: "1 is not iterable"},
+		},
+		{
+			name: "c style for loop still works",
+			input: `
+			let sum = 0;
+			for (let i = 0; i < 4; i++) {
+				sum += i;
+			}
+			return sum;
+			`,
+			want: &object.Integer{Value: 6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestEvalBreak(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "while",
+			input: `
+			let i = 0;
+			while (true) {
+				if (i == 3) { break; }
+				i += 1;
+			}
+			return i;
+			`,
+			want: &object.Integer{Value: 3},
+		},
+		{
+			name: "for without clauses",
+			input: `
+			let i = 0;
+			for (;;) {
+				i += 1;
+				if (i == 5) break;
+			}
+			return i;
+			`,
+			want: &object.Integer{Value: 5},
+		},
+		{
+			name: "break skips the increment",
+			input: `
+			let last = 0;
+			for (let i = 0; i < 10; i++) {
+				last = i;
+				if (i == 2) { break; }
+			}
+			return last;
+			`,
+			want: &object.Integer{Value: 2},
+		},
+		{
+			name: "for-in stops the innermost loop",
+			input: `
+			let out = [];
+			for (x in [1, 2, 3]) {
+				for (y in [1, 2, 3]) {
+					if (y > x) { break; }
+					out.push(x * 10 + y);
+				}
+			}
+			return out;
+			`,
+			want: intSlice(11, 21, 22, 31, 32, 33),
+		},
+		{
+			name: "match arm",
+			input: `
+			let n = 0;
+			while (true) {
+				match (n) {
+					3 => { break; }
+					_ => { n += 1; }
+				}
+			}
+			return n;
+			`,
+			want: &object.Integer{Value: 3},
+		},
+		{
+			name: "for-in closes the generator",
+			input: naturalsGen + `
+			let g = naturals();
+			for (x in g) {
+				if (x == 1) { break; }
+			}
+			return g.next();
+			`,
+			want: nextResultMap(true, &object.Null{}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+var (
+	ErrNoMatchArm = "no match arm for value: %s"
+)
+
+// evalMatch evaluates the subject once, then runs the body of the first arm which matches it
+func evalMatch(m *ast.Match, env *object.Environment) (object.Object, error) {
+	subject, err := Eval(m.Subject, env)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, arm := range m.Arms {
+		// the bindings of the arm are only visible in its guard and body
+		armEnv := object.NewBlockEnv(env)
+
+		matched := false
+		for _, pattern := range arm.Patterns {
+			ok, err := matchPattern(pattern, subject, armEnv)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		if arm.Guard != nil {
+			truth, err := evalCondition(arm.Guard, armEnv)
+			if err != nil {
+				return nil, err
+			}
+			if !truth {
+				continue
+			}
+		}
+
+		return Eval(arm.Body, armEnv)
+	}
+
+	return nil, fmt.Errorf(ErrNoMatchArm, inspect(subject))
+}
+
+func matchPattern(pattern *ast.MatchPattern, subject object.Object, env *object.Environment) (bool, error) {
+	switch pattern.Kind {
+	case ast.PatternBinding:
+		if !pattern.IsWildcard() {
+			env.Set(pattern.Name.Literal, subject)
+		}
+		return true, nil
+	case ast.PatternLiteral:
+		value, err := evalLiteral(pattern.Value)
+		if err != nil {
+			return false, err
+		}
+
+		return objectEquals(subject, value)
+	case ast.PatternRange:
+		low, err := evalLiteral(pattern.Value)
+		if err != nil {
+			return false, err
+		}
+		high, err := evalLiteral(pattern.High)
+		if err != nil {
+			return false, err
+		}
+
+		highOp := tokens.LT
+		if pattern.Inclusive {
+			highOp = tokens.LTEQ
+		}
+
+		// the value which can not be compared with the bounds is not in the range
+		if ok, err := compareObj(subject, low, tokens.GTEQ); err != nil || !ok {
+			return false, nil
+		}
+		if ok, err := compareObj(subject, high, highOp); err != nil || !ok {
+			return false, nil
+		}
+		return true, nil
+	}
+
+	return false, fmt.Errorf("unknown match pattern: %v", pattern.Kind)
+}
@@ -0,0 +1,146 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func TestEvalMatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "literal patterns",
+			input: `
+			fn name(n) {
+				return match (n) {
+					1, 2 => "small",
+					"x" => "letter",
+					null => "nothing",
+					_ => "other",
+				};
+			}
+			return [name(2), name("x"), name(null), name(2.0), name(10)];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "small"},
+				&object.String{Value: "letter"},
+				&object.String{Value: "nothing"},
+				&object.String{Value: "small"},
+				&object.String{Value: "other"},
+			}},
+		},
+		{
+			name: "range patterns",
+			input: `
+			fn grade(score) {
+				return match (score) {
+					90..=100 => "A",
+					60..90 => "B",
+					-10..0 => "negative",
+					_ => "C",
+				};
+			}
+			return [grade(100), grade(90), grade(89.5), grade(-3), grade(10), grade("x")];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "A"},
+				&object.String{Value: "A"},
+				&object.String{Value: "B"},
+				&object.String{Value: "negative"},
+				&object.String{Value: "C"},
+				&object.String{Value: "C"},
+			}},
+		},
+		{
+			name: "binding and guard",
+			input: `
+			fn describe(n) {
+				return match (n) {
+					x if x > 100 => x * 2,
+					x => x + 1
+				};
+			}
+			return describe(200) + describe(1);
+			`,
+			want: &object.Integer{Value: 402},
+		},
+		{
+			name: "match statement with block arms",
+			input: `
+			fn sign(n) {
+				match (n) {
+					0 => {
+						return "zero";
+					}
+					x if x < 0 => {
+						return "negative";
+					}
+					_ => {
+						return "positive";
+					}
+				}
+				return "unreachable";
+			}
+			return [sign(0), sign(-1), sign(5)];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "zero"},
+				&object.String{Value: "negative"},
+				&object.String{Value: "positive"},
+			}},
+		},
+		{
+			name: "subject is evaluated once",
+			input: `
+			let calls = 0;
+			fn next() {
+				calls = calls + 1;
+				return calls;
+			}
+			match (next()) {
+				2 => "two",
+				3 => "three",
+				_ => "other",
+			}
+			return calls;
+			`,
+			want: &object.Integer{Value: 1},
+		},
+		{
+			name: "bool match is exhaustive",
+			input: `
+			let b = 1 < 2;
+			return match (b) { true => "yes", false => "no" };
+			`,
+			want: &object.String{Value: "yes"},
+		},
+		{
+			name: "else if chain",
+			input: `
+			let n = 5;
+			if (n < 3) {
+				return "small";
+			} else if (n < 10) {
+				return "medium";
+			} else {
+				return "large";
+			}
+			`,
+			want: &object.String{Value: "medium"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
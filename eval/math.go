@@ -0,0 +1,238 @@
+package eval
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+const (
+	moduleMath = "math"
+)
+
+var (
+	ErrNotNumber       = "%s: %s is not a number"
+	ErrFloatToInteger  = "%s: %v can not be converted to integer"
+	ErrEmptyRandRange  = "randInt: empty range [%d, %d)"
+	ErrAbsIntegerRange = "abs: integer overflow: %d"
+)
+
+// randSource is the random generator of an interpreter used by math.random and math.randInt
+type randSource struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func newRandSource() *randSource {
+	return &randSource{rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// seed resets the generator, the same seed always produces the same sequence
+func (r *randSource) seed(seed int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rng = rand.New(rand.NewSource(seed))
+}
+
+func (r *randSource) float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rng.Float64()
+}
+
+func (r *randSource) int63n(n int64) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rng.Int63n(n)
+}
+
+func newMathModule(rs *randSource) *object.Module {
+	members := map[string]object.Object{
+		"PI": &object.Float{Value: math.Pi},
+		"E":  &object.Float{Value: math.E},
+	}
+
+	fns := map[string]object.BuiltinFunction{
+		"abs":     mathAbs,
+		"min":     mathMinMax("min", lessFloat),
+		"max":     mathMinMax("max", greaterFloat),
+		"floor":   mathToInteger("floor", math.Floor),
+		"ceil":    mathToInteger("ceil", math.Ceil),
+		"round":   mathToInteger("round", math.Round),
+		"sqrt":    mathFloatFn("sqrt", math.Sqrt),
+		"sin":     mathFloatFn("sin", math.Sin),
+		"cos":     mathFloatFn("cos", math.Cos),
+		"tan":     mathFloatFn("tan", math.Tan),
+		"log":     mathFloatFn("log", math.Log),
+		"exp":     mathFloatFn("exp", math.Exp),
+		"random":  mathRandom(rs),
+		"randInt": mathRandInt(rs),
+		"seed":    mathSeed(rs),
+	}
+	for name, fn := range fns {
+		members[name] = &object.Builtin{Name: moduleMath + "." + name, Fn: fn}
+	}
+
+	return object.NewModule(moduleMath, members)
+}
+
+func numberArg(name string, arg object.Object) (float64, error) {
+	f, ok := toFloat(arg)
+	if !ok {
+		return 0, fmt.Errorf(ErrNotNumber, name, inspect(arg))
+	}
+	return f, nil
+}
+
+func mathAbs(args ...object.Object) (object.Object, error) {
+	if err := checkArgsCount("abs", args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	switch v := args[0].(type) {
+	case *object.Integer:
+		if v.Value == math.MinInt64 {
+			return nil, fmt.Errorf(ErrAbsIntegerRange, v.Value)
+		}
+		if v.Value < 0 {
+			return &object.Integer{Value: -v.Value}, nil
+		}
+		return v, nil
+	case *object.BigInt:
+		return normalizeBigInt(new(big.Int).Abs(v.Value)), nil
+	case *object.Float:
+		return &object.Float{Value: math.Abs(v.Value)}, nil
+	}
+
+	return nil, fmt.Errorf(ErrNotNumber, "abs", inspect(args[0]))
+}
+
+func lessFloat(a, b float64) bool    { return a < b }
+func greaterFloat(a, b float64) bool { return a > b }
+
+// mathMinMax returns the min/max function, it accepts numbers: min(1, 2) or a slice: min([1, 2])
+func mathMinMax(name string, better func(a, b float64) bool) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		if err := checkArgsCount(name, args, 1, -1); err != nil {
+			return nil, err
+		}
+
+		values := args
+		if sl, ok := args[0].(*object.Slice); ok && len(args) == 1 {
+			values = sl.Elements
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%s: no values", name)
+		}
+
+		result := values[0]
+		best, err := numberArg(name, result)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range values[1:] {
+			f, err := numberArg(name, v)
+			if err != nil {
+				return nil, err
+			}
+
+			if better(f, best) {
+				result, best = v, f
+			}
+		}
+
+		return result, nil
+	}
+}
+
+// mathToInteger returns the function which rounds a number to integer with fn
+func mathToInteger(name string, fn func(float64) float64) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		if err := checkArgsCount(name, args, 1, 1); err != nil {
+			return nil, err
+		}
+
+		switch v := args[0].(type) {
+		case *object.Integer, *object.BigInt:
+			return v, nil
+		case *object.Float:
+			f := fn(v.Value)
+			if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return nil, fmt.Errorf(ErrFloatToInteger, name, v.Value)
+			}
+			return &object.Integer{Value: int64(f)}, nil
+		}
+
+		return nil, fmt.Errorf(ErrNotNumber, name, inspect(args[0]))
+	}
+}
+
+func mathFloatFn(name string, fn func(float64) float64) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		if err := checkArgsCount(name, args, 1, 1); err != nil {
+			return nil, err
+		}
+
+		f, err := numberArg(name, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return &object.Float{Value: fn(f)}, nil
+	}
+}
+
+// random() returns a float in [0, 1)
+func mathRandom(rs *randSource) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		if err := checkArgsCount("random", args, 0, 0); err != nil {
+			return nil, err
+		}
+
+		return &object.Float{Value: rs.float64()}, nil
+	}
+}
+
+// randInt(low, high) returns an integer in [low, high)
+func mathRandInt(rs *randSource) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		if err := checkArgsCount("randInt", args, 2, 2); err != nil {
+			return nil, err
+		}
+
+		low, err := intArg("randInt", args[0])
+		if err != nil {
+			return nil, err
+		}
+		high, err := intArg("randInt", args[1])
+		if err != nil {
+			return nil, err
+		}
+		if high <= low || high-low <= 0 {
+			return nil, fmt.Errorf(ErrEmptyRandRange, low, high)
+		}
+
+		return &object.Integer{Value: low + rs.int63n(high-low)}, nil
+	}
+}
+
+// seed(n) resets the generator of the interpreter
+func mathSeed(rs *randSource) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		if err := checkArgsCount("seed", args, 1, 1); err != nil {
+			return nil, err
+		}
+
+		seed, err := intArg("seed", args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		rs.seed(seed)
+		return &object.Null{}, nil
+	}
+}
@@ -0,0 +1,178 @@
+package eval
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func TestEvalMath(t *testing.T) {
+	type args struct {
+		input string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want object.Object
+	}{
+		{
+			name: "modulo",
+			args: args{
+				input: `return 17 % 5 + -7 % 3;`,
+			},
+			want: &object.Integer{Value: 1},
+		},
+		{
+			name: "modulo by zero",
+			args: args{
+				input: `return 1 % 0;`,
+			},
+			want: &object.Error{Message: ErrDivideByZero},
+		},
+		{
+			name: "modulo has the same precedence with *",
+			args: args{
+				input: `return 2 + 10 % 4 * 3;`,
+			},
+			want: &object.Integer{Value: 8},
+		},
+		{
+			name: "exact integer pow",
+			args: args{
+				input: `return 3 ** 39;`,
+			},
+			want: &object.Integer{Value: 4052555153018976267},
+		},
+		{
+			name: "integer pow overflow",
+			args: args{
+				input: `return 2 ** 63;`,
+			},
+			want: &object.Error{Message: "integer overflow: 2 ** 63"},
+		},
+		{
+			name: "negative exponent",
+			args: args{
+				input: `return 2 ** -2;`,
+			},
+			want: &object.Float{Value: 0.25},
+		},
+		{
+			name: "float arithmetic",
+			args: args{
+				input: `return 1.5 * 2 + 0.25;`,
+			},
+			want: &object.Float{Value: 3.25},
+		},
+		{
+			name: "compare integer with float",
+			args: args{
+				input: `return 1 < 1.5;`,
+			},
+			want: &object.Bool{Value: true},
+		},
+		{
+			name: "abs",
+			args: args{
+				input: `return math.abs(-3) + math.abs(3);`,
+			},
+			want: &object.Integer{Value: 6},
+		},
+		{
+			name: "min and max",
+			args: args{
+				input: `return [math.min(3, 1, 2), math.max([1, 5, 2]), math.max(1, 2.5)];`,
+			},
+			want: &object.Slice{Elements: []object.Object{
+				&object.Integer{Value: 1},
+				&object.Integer{Value: 5},
+				&object.Float{Value: 2.5},
+			}},
+		},
+		{
+			name: "floor ceil round",
+			args: args{
+				input: `return [math.floor(2.7), math.ceil(2.1), math.round(-2.5), math.floor(3)];`,
+			},
+			want: intSlice(2, 3, -3, 3),
+		},
+		{
+			name: "sqrt",
+			args: args{
+				input: `return math.sqrt(16);`,
+			},
+			want: &object.Float{Value: 4},
+		},
+		{
+			name: "trigonometric and constants",
+			args: args{
+				input: `return math.sin(math.PI / 2) + math.cos(0) + math.log(math.E) + math.exp(0) + math.tan(0);`,
+			},
+			want: &object.Float{Value: 4},
+		},
+		{
+			name: "not a number",
+			args: args{
+				input: `return math.sqrt("16");`,
+			},
+			want: &object.Error{Message: "sqrt: 16 is not a number"},
+		},
+		{
+			name: "unknown member",
+			args: args{
+				input: `return math.foo;`,
+			},
+			want: &object.Error{Message: "module math has no member: foo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.args.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestEvalMathRandom(t *testing.T) {
+	input := `
+	math.seed(7);
+	return [math.randInt(0, 1000), math.randInt(0, 1000), math.random()];
+	`
+
+	first, err := testEvalInput(input)
+	assert.Nil(t, err)
+	second, err := testEvalInput(input)
+	assert.Nil(t, err)
+	assert.Equal(t, first, second)
+
+	sl, ok := first.(*object.Slice)
+	if assert.True(t, ok) && assert.Equal(t, 3, len(sl.Elements)) {
+		f := sl.Elements[2].(*object.Float).Value
+		assert.True(t, f >= 0 && f < 1 && !math.IsNaN(f))
+	}
+
+	obj, err := testEvalInput(`return math.randInt(5, 5);`)
+	assert.Nil(t, err)
+	assert.Equal(t, &object.Error{Message: "randInt: empty range [5, 5)"}, obj)
+}
+
+func TestEvalMathRandomPerInterpreter(t *testing.T) {
+	want, err := testEvalProgram(`math.seed(7); return [math.randInt(0, 1000000), math.randInt(0, 1000000)];`, NewEnvironment(nil))
+	assert.Nil(t, err)
+
+	// seeding another interpreter in between does not change the sequence of the first one
+	first, second := NewEnvironment(nil), NewEnvironment(nil)
+	a, err := testEvalProgram(`math.seed(7); return math.randInt(0, 1000000);`, first)
+	assert.Nil(t, err)
+	_, err = testEvalProgram(`math.seed(8); return math.randInt(0, 1000000);`, second)
+	assert.Nil(t, err)
+	b, err := testEvalProgram(`return math.randInt(0, 1000000);`, first)
+	assert.Nil(t, err)
+	assert.Equal(t, want, &object.Slice{Elements: []object.Object{a, b}})
+}
@@ -0,0 +1,128 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+type OverflowMode int
+
+const (
+	// OverflowError raises a runtime error when integer arithmetic overflows int64
+	OverflowError OverflowMode = iota
+	// OverflowBigInt promotes the result to object.BigInt when it overflows int64
+	OverflowBigInt
+)
+
+var overflowModeNames = map[OverflowMode]string{
+	OverflowError:  "error",
+	OverflowBigInt: "bigint",
+}
+
+func (m OverflowMode) String() string {
+	return overflowModeNames[m]
+}
+
+// Set parses the name of the mode, error or bigint, so OverflowMode can be a command line flag
+func (m *OverflowMode) Set(name string) error {
+	for mode, n := range overflowModeNames {
+		if n == name {
+			*m = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown integer overflow mode: %s, want error or bigint", name)
+}
+
+// Options configures the behaviour of the interpreter
+type Options struct {
+	IntOverflow OverflowMode
+	// SearchPath is the list of directories to look up an imported module,
+	// after the directory of the importing file
+	SearchPath []string
+	// Capabilities grants scripts the access to the host through the os and fs modules, and imports
+	Capabilities Capabilities
+	// StrictBool requires the conditions, and the operands of !, && and ||, to be bool values,
+	// instead of using the truthiness of the value
+	StrictBool bool
+	// Optimizer rewrites each program, including the imported modules, after it is resolved and before it runs,
+	// like optimize.Program, the programs run as they are parsed if it is nil
+	Optimizer func(program *ast.Program, opts *Options) *ast.Program
+}
+
+// Capabilities is what a script is allowed to do on the host, the zero value denies everything
+type Capabilities struct {
+	// FSRoots are the directories which the fs module can access,
+	// relative paths in scripts are resolved against the first one
+	FSRoots []string
+	// ReadOnly denies writing files under FSRoots
+	ReadOnly bool
+	// ImportRoots are the directories which modules can be imported from,
+	// besides SearchPath and FSRoots
+	ImportRoots []string
+	// EnvAllowlist is the names of the env variables which os.env can read
+	EnvAllowlist []string
+	// Args is returned by os.args()
+	Args []string
+	// Exit allows the script to stop itself by os.exit(code)
+	Exit bool
+}
+
+// interpreter is the state shared by all the envs of one interpreter,
+// it is kept as the settings of the global env
+type interpreter struct {
+	opts   *Options
+	loader *moduleLoader
+	// modules are the builtin modules which depend on the options or keep the state of the interpreter
+	modules map[string]*object.Module
+	// generators are closed by Close
+	generators *generatorSet
+}
+
+func newInterpreter(opts *Options) *interpreter {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	return &interpreter{
+		opts:       opts,
+		loader:     newModuleLoader(),
+		generators: newGeneratorSet(),
+		modules: map[string]*object.Module{
+			moduleOS: newOSModule(&opts.Capabilities),
+			moduleFS: newFSModule(&opts.Capabilities),
+			// each interpreter has its own random generator, seeding one does not change the others
+			moduleMath: newMathModule(newRandSource()),
+		},
+	}
+}
+
+// defaultInterpreter is used by the envs which are not created by NewEnvironment
+var defaultInterpreter = newInterpreter(nil)
+
+// NewEnvironment creates the global environment of an interpreter configured by opts
+func NewEnvironment(opts *Options) *object.Environment {
+	return object.NewEnvironmentWithSettings(newInterpreter(opts))
+}
+
+// Close stops the interpreter of env, the generators created by it are closed and their goroutines exit.
+// the envs of the interpreter can still run programs, but the generators can not be resumed
+func Close(env *object.Environment) {
+	interpreterOf(env).generators.closeAll()
+}
+
+func interpreterOf(env *object.Environment) *interpreter {
+	switch s := env.Settings().(type) {
+	case *interpreter:
+		return s
+	case *scriptState:
+		return s.interpreter
+	}
+	return defaultInterpreter
+}
+
+func optionsOf(env *object.Environment) *Options {
+	return interpreterOf(env).opts
+}
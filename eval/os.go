@@ -0,0 +1,110 @@
+package eval
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+const (
+	moduleOS = "os"
+)
+
+var (
+	ErrEnvNotAllowed  = "os.env: access to %s is not allowed"
+	ErrExitNotAllowed = "os.exit is not allowed"
+)
+
+// ExitError is returned by Eval when the script calls os.exit(code)
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+func newOSModule(caps *Capabilities) *object.Module {
+	fns := map[string]object.BuiltinFunction{
+		"env":  osEnv(caps),
+		"args": osArgs(caps),
+		"exit": osExit(caps),
+	}
+
+	members := make(map[string]object.Object, len(fns))
+	for name, fn := range fns {
+		members[name] = &object.Builtin{Name: moduleOS + "." + name, Fn: fn}
+	}
+	return object.NewModule(moduleOS, members)
+}
+
+// os.env(name) returns the value of the env variable, or null if it is not set
+func osEnv(caps *Capabilities) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		if err := checkArgsCount("os.env", args, 1, 1); err != nil {
+			return nil, err
+		}
+
+		name, ok := args[0].(*object.String)
+		if !ok {
+			return nil, fmt.Errorf("os.env: %s is not a string", inspect(args[0]))
+		}
+
+		if !contains(caps.EnvAllowlist, name.Value) {
+			return nil, fmt.Errorf(ErrEnvNotAllowed, name.Value)
+		}
+
+		value, ok := os.LookupEnv(name.Value)
+		if !ok {
+			return &object.Null{}, nil
+		}
+		return &object.String{Value: value}, nil
+	}
+}
+
+// os.args() returns the command-line arguments passed to the script
+func osArgs(caps *Capabilities) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		if err := checkArgsCount("os.args", args, 0, 0); err != nil {
+			return nil, err
+		}
+
+		elements := make([]object.Object, 0, len(caps.Args))
+		for _, arg := range caps.Args {
+			elements = append(elements, &object.String{Value: arg})
+		}
+		return &object.Slice{Elements: elements}, nil
+	}
+}
+
+// os.exit(code?) stops the script, the host decides what to do with the ExitError
+func osExit(caps *Capabilities) object.BuiltinFunction {
+	return func(args ...object.Object) (object.Object, error) {
+		if err := checkArgsCount("os.exit", args, 0, 1); err != nil {
+			return nil, err
+		}
+
+		if !caps.Exit {
+			return nil, fmt.Errorf(ErrExitNotAllowed)
+		}
+
+		var code int64
+		if len(args) == 1 {
+			var err error
+			if code, err = intArg("os.exit", args[0]); err != nil {
+				return nil, err
+			}
+		}
+		return nil, &ExitError{Code: int(code)}
+	}
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
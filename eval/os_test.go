@@ -0,0 +1,73 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func TestOSModule(t *testing.T) {
+	t.Setenv("SI_TEST_VAR", "value")
+
+	tests := []struct {
+		name    string
+		input   string
+		caps    Capabilities
+		want    object.Object
+		wantErr error
+	}{
+		{
+			name: "env",
+			input: `
+			return [os.env("SI_TEST_VAR"), os.env("SI_TEST_UNSET")];
+			`,
+			caps: Capabilities{EnvAllowlist: []string{"SI_TEST_VAR", "SI_TEST_UNSET"}},
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "value"},
+				&object.Null{},
+			}},
+		},
+		{
+			name: "env not allowed",
+			input: `
+			return os.env("SI_TEST_VAR");
+			`,
+			want: &object.Error{Message: "os.env: access to SI_TEST_VAR is not allowed"},
+		},
+		{
+			name: "args",
+			input: `
+			return os.args();
+			`,
+			caps: Capabilities{Args: []string{"-v"}},
+			want: &object.Slice{Elements: []object.Object{&object.String{Value: "-v"}}},
+		},
+		{
+			name: "exit",
+			input: `
+			fn stop() { os.exit(3); }
+			stop();
+			return 1;
+			`,
+			caps:    Capabilities{Exit: true},
+			wantErr: &ExitError{Code: 3},
+		},
+		{
+			name: "exit not allowed",
+			input: `
+			os.exit();
+			`,
+			want: &object.Error{Message: ErrExitNotAllowed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalWithOptions(tt.input, &Options{Capabilities: tt.caps})
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+// the special methods which a class defines to overload the operators and builtins for its instances,
+// the instance must be the left operand of a binary operator.
+const (
+	methodEq    = "__eq__"    // a == b, a != b
+	methodLt    = "__lt__"    // a < b, and a <= b, a > b, a >= b when they are not defined
+	methodLe    = "__le__"    // a <= b
+	methodGt    = "__gt__"    // a > b
+	methodGe    = "__ge__"    // a >= b
+	methodNeg   = "__neg__"   // -a
+	methodIndex = "__index__" // a[key]
+	methodStr   = "__str__"   // print
+	methodLen   = "__len__"   // len(a)
+)
+
+var arithmeticMethods = map[tokens.TokenType]string{
+	tokens.PLUS:     "__add__",
+	tokens.MINUS:    "__sub__",
+	tokens.ASTERISK: "__mul__",
+	tokens.SLASH:    "__div__",
+	tokens.PERCENT:  "__mod__",
+	tokens.POW:      "__pow__",
+}
+
+var orderMethods = map[tokens.TokenType]string{
+	tokens.LT:   methodLt,
+	tokens.LTEQ: methodLe,
+	tokens.GT:   methodGt,
+	tokens.GTEQ: methodGe,
+}
+
+// specialMethod returns the method bound to obj, if obj is a class instance and its class defines the method
+func specialMethod(obj object.Object, name string) (object.Object, bool) {
+	inst, ok := obj.(*object.ClassInstance)
+	if !ok {
+		return nil, false
+	}
+
+	if _, defined := inst.Cls.Methods[name]; !defined {
+		return nil, false
+	}
+
+	method, err := inst.Get(tokens.NewIdentToken(name))
+	if err != nil {
+		return nil, false
+	}
+	return method, true
+}
+
+// callBoolMethod calls the special method of inst, which must return a bool value
+func callBoolMethod(inst *object.ClassInstance, name string, args ...object.Object) (bool, error) {
+	method, ok := specialMethod(inst, name)
+	if !ok {
+		return false, fmt.Errorf(object.ErrPropertyNotFound, name, inst.Inspect())
+	}
+
+	result, err := applyFunction(method, args)
+	if err != nil {
+		return false, err
+	}
+
+	truth, ok := result.(*object.Bool)
+	if !ok {
+		return false, fmt.Errorf("%s.%s must return a bool value, got: %s", inst.Cls.Name, name, inspect(result))
+	}
+	return truth.Value, nil
+}
+
+// instanceOrder compares the order of inst and other by the methods of the class,
+// ok is false when the class does not define the order.
+func instanceOrder(inst *object.ClassInstance, other object.Object, op tokens.TokenType) (result bool, ok bool, err error) {
+	if _, defined := specialMethod(inst, orderMethods[op]); defined {
+		result, err = callBoolMethod(inst, orderMethods[op], other)
+		return result, true, err
+	}
+
+	if _, defined := specialMethod(inst, methodLt); !defined {
+		return false, false, nil
+	}
+
+	less, err := callBoolMethod(inst, methodLt, other)
+	if err != nil {
+		return false, true, err
+	}
+
+	switch op {
+	case tokens.GTEQ:
+		return !less, true, nil
+	case tokens.LTEQ, tokens.GT:
+		eq := false
+		if !less {
+			if eq, err = objectEquals(inst, other); err != nil {
+				return false, true, err
+			}
+		}
+		lessOrEqual := less || eq
+		return lessOrEqual == (op == tokens.LTEQ), true, nil
+	}
+	return less, true, nil
+}
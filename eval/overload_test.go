@@ -0,0 +1,154 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+const vectorClass = `
+class Vec {
+	__add__(other) { return vec(this.x + other.x, this.y + other.y); }
+	__sub__(other) { return vec(this.x - other.x, this.y - other.y); }
+	__mul__(k) { return vec(this.x * k, this.y * k); }
+	__neg__() { return vec(-this.x, -this.y); }
+	__eq__(other) { return this.x == other.x && this.y == other.y; }
+	__index__(i) { return i == 0 ? this.x : this.y; }
+	__len__() { return 2; }
+	__str__() { return "Vec(" + str(this.x) + ", " + str(this.y) + ")"; }
+}
+
+fn vec(x, y) {
+	let v = Vec();
+	v.x = x;
+	v.y = y;
+	return v;
+}
+`
+
+const moneyClass = `
+class Money {
+	__lt__(other) { return this.cents < other.cents; }
+	__eq__(other) { return this.cents == other.cents; }
+}
+
+fn money(cents) {
+	let m = Money();
+	m.cents = cents;
+	return m;
+}
+`
+
+func TestEvalOperatorOverloading(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "arithmetic",
+			input: vectorClass + `
+			let v = vec(1, 2) + vec(3, 4) * 2 - vec(1, 1);
+			return [v.x, v.y];
+			`,
+			want: intSlice(6, 9),
+		},
+		{
+			name: "negation",
+			input: vectorClass + `
+			let v = -vec(1, -2);
+			return [v.x, v.y];
+			`,
+			want: intSlice(-1, 2),
+		},
+		{
+			name: "equality",
+			input: vectorClass + `
+			return [vec(1, 2) == vec(1, 2), vec(1, 2) != vec(2, 1), [vec(0, 0), vec(1, 2)].indexOf(vec(1, 2)) == 1];
+			`,
+			want: boolSlice(true, true, true),
+		},
+		{
+			name: "index and len",
+			input: vectorClass + `
+			let v = vec(7, 8);
+			return [v[0], v[1], len(v)];
+			`,
+			want: intSlice(7, 8, 2),
+		},
+		{
+			name: "order derived from __lt__ and __eq__",
+			input: moneyClass + `
+			let a = money(100);
+			let b = money(250);
+			return [a < b, a <= b, a > b, a >= b, b > a, a <= money(100), a >= money(100), a > money(100)];
+			`,
+			want: boolSlice(true, true, false, false, true, true, true, false),
+		},
+		{
+			name: "sort by __lt__",
+			input: moneyClass + `
+			let xs = sort([money(3), money(1), money(2)]);
+			return [xs[0].cents, xs[1].cents, xs[2].cents];
+			`,
+			want: intSlice(1, 2, 3),
+		},
+		{
+			name: "__le__ is preferred over __lt__",
+			input: `
+			class Always {
+				__lt__(other) { return false; }
+				__le__(other) { return true; }
+			}
+			return Always() <= 1;
+			`,
+			want: &object.Bool{Value: true},
+		},
+		{
+			name: "operator is not defined",
+			input: vectorClass + `
+			return vec(1, 2) / 2;
+			`,
+			want: &object.Error{Message: "Vec{x: 1, y: 2} must be number"},
+		},
+		{
+			name: "comparison must return bool",
+			input: `
+			class Bad {
+				__lt__(other) { return 1; }
+			}
+			return Bad() < Bad();
+			`,
+			want: &object.Error{Message: "Bad.__lt__ must return a bool value, got: 1"},
+		},
+		{
+			name: "__len__ must return integer",
+			input: `
+			class Bad {
+				__len__() { return "1"; }
+			}
+			return len(Bad());
+			`,
+			want: &object.Error{Message: "len: __len__ must return an integer, got: 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
+
+func TestPrintValueOfInstance(t *testing.T) {
+	obj, err := testEvalInput(vectorClass + `return vec(1, 2);`)
+	assert.Nil(t, err)
+
+	v, err := getValueLiteral(obj)
+	assert.Nil(t, err)
+	assert.Equal(t, "Vec(1, 2)", v)
+}
@@ -1,16 +1,317 @@
 package eval
 
 import (
+	"fmt"
+
 	"github.com/forfd8960/simpleinterpreter/ast"
 	"github.com/forfd8960/simpleinterpreter/object"
 )
 
+type declKind int
+
+const (
+	declVar   declKind = iota // let, fn, class and function parameters
+	declConst                 // const and import
+)
+
+// scope is the names declared in a block, function or program
+type scope map[string]declKind
+
+type resolver struct {
+	scopes *Stack
+	// function is the innermost function around the current statement, nil at the top level
+	function *ast.Function
+}
+
 // Resolve Semantic Analysis
 // tracks down which declaration it refers to—each and every time the variable expression is evaluated
 // https://craftinginterpreters.com/resolving-and-binding.html#a-resolver-class
 /*
- If we could ensure a variable lookup always walked the same number of links in the environment chain,
- that would ensure that it found the same variable in the same scope every time.
+ The resolver walks the program once before it runs, and reports the errors which can be found statically:
+ a variable declared twice in the same scope, and an assignment to a constant.
+ The names declared by the previous programs (like the lines in the REPL) are checked at runtime.
+ It also marks `return f(...)` in functions as tail calls.
 */
-func Resolve(node ast.Node, env *object.Environment, stack *Stack) {
+func Resolve(program *ast.Program) error {
+	r := &resolver{scopes: NewStack()}
+	r.beginScope()
+	return r.resolveStmts(program.Stmts)
+}
+
+func (r *resolver) beginScope() {
+	r.scopes.Push(scope{})
+}
+
+func (r *resolver) endScope() {
+	r.scopes.Pop()
+}
+
+func (r *resolver) declare(name string, kind declKind) error {
+	current := r.scopes.Peek().(scope)
+	if _, ok := current[name]; ok {
+		return fmt.Errorf(object.ErrRedeclared, name)
+	}
+
+	current[name] = kind
+	return nil
+}
+
+// redeclare declares the name of a function or class, which can be declared again unless it is a constant
+func (r *resolver) redeclare(name string) error {
+	current := r.scopes.Peek().(scope)
+	if kind, ok := current[name]; ok && kind == declConst {
+		return fmt.Errorf(object.ErrRedeclared, name)
+	}
+
+	current[name] = declVar
+	return nil
+}
+
+// assign checks the closest declaration of name is not a constant
+func (r *resolver) assign(name string) error {
+	for idx := r.scopes.Len() - 1; idx >= 0; idx-- {
+		kind, ok := r.scopes.At(idx).(scope)[name]
+		if !ok {
+			continue
+		}
+
+		if kind == declConst {
+			return fmt.Errorf(object.ErrAssignToConst, name)
+		}
+		return nil
+	}
+	return nil
+}
+
+// assignTarget checks the target of +=, ++ and the others is not a constant
+func (r *resolver) assignTarget(target ast.Expression) error {
+	if ident, ok := target.(*ast.Identifier); ok {
+		return r.assign(ident.Name)
+	}
+	return nil
+}
+
+func (r *resolver) resolveStmts(stmts []ast.Stmt) error {
+	for _, stmt := range stmts {
+		if err := r.resolveStmt(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *resolver) resolveStmt(stmt ast.Stmt) error {
+	switch v := stmt.(type) {
+	case *ast.LetStmt:
+		if err := r.resolveExpr(v.InitExpr); err != nil {
+			return err
+		}
+
+		kind := declVar
+		if v.Const {
+			kind = declConst
+		}
+		return r.declare(v.Ident.Name, kind)
+	case *ast.Function:
+		if err := r.redeclare(v.Name.Literal); err != nil {
+			return err
+		}
+		return r.resolveFunction(v)
+	case *ast.ClassStmt:
+		if err := r.redeclare(v.NameIdent.Name); err != nil {
+			return err
+		}
+		for _, method := range v.Methods {
+			if err := r.resolveFunction(method); err != nil {
+				return err
+			}
+		}
+	case *ast.Block:
+		r.beginScope()
+		defer r.endScope()
+		return r.resolveStmts(v.Statements)
+	case *ast.ExpressionStmt:
+		return r.resolveExpr(v.Expr)
+	case *ast.IFStmt:
+		if err := r.resolveExpr(v.Condition); err != nil {
+			return err
+		}
+		if err := r.resolveStmt(v.ThenBranch); err != nil {
+			return err
+		}
+		if v.ElseBranch != nil {
+			return r.resolveStmt(v.ElseBranch)
+		}
+	case *ast.WhileStmt:
+		if err := r.resolveExpr(v.Condition); err != nil {
+			return err
+		}
+		return r.resolveStmt(v.Body)
+	case *ast.ForStmt:
+		return r.resolveStmt(v.Loop)
+	case *ast.ForInStmt:
+		if err := r.resolveExpr(v.Iterable); err != nil {
+			return err
+		}
+		return r.resolveForIn(v)
+	case *ast.ReturnStmt:
+		// the return value of a generator is not the result of a call, so it can not be a tail call
+		if _, isCall := v.Value.(*ast.Call); isCall && r.function != nil && !r.function.Generator {
+			v.TailCall = true
+		}
+		return r.resolveExpr(v.Value)
+	case *ast.YieldStmt:
+		return r.resolveExpr(v.Value)
+	case *ast.PrintStmt:
+		return r.resolveExprs(v.Values)
+	case *ast.ImportStmt:
+		if v.Names != nil {
+			for _, name := range v.Names {
+				if err := r.declare(name.Literal, declConst); err != nil {
+					return err
+				}
+			}
+		} else if name := moduleVarName(v); isIdentifier(name) {
+			return r.declare(name, declConst)
+		}
+	case *ast.ExportStmt:
+		return r.resolveStmt(v.Stmt)
+	}
+
+	return nil
+}
+
+func (r *resolver) resolveFunction(fn *ast.Function) error {
+	r.beginScope()
+	defer r.endScope()
+
+	outer := r.function
+	r.function = fn
+	defer func() { r.function = outer }()
+
+	for _, param := range fn.Parameters {
+		if err := r.declare(param.Literal, declVar); err != nil {
+			return err
+		}
+	}
+	if fn.Rest != nil {
+		if err := r.declare(fn.Rest.Literal, declVar); err != nil {
+			return err
+		}
+	}
+	if err := r.resolveExprs(fn.Defaults); err != nil {
+		return err
+	}
+	return r.resolveStmt(fn.Body)
+}
+
+// resolveForIn declares the loop variables in the scope of each iteration
+func (r *resolver) resolveForIn(forIn *ast.ForInStmt) error {
+	r.beginScope()
+	defer r.endScope()
+
+	if forIn.Key != nil {
+		if err := r.declare(forIn.Key.Name, declVar); err != nil {
+			return err
+		}
+	}
+	if err := r.declare(forIn.Value.Name, declVar); err != nil {
+		return err
+	}
+	return r.resolveStmt(forIn.Body)
+}
+
+func (r *resolver) resolveMatchArm(arm *ast.MatchArm) error {
+	r.beginScope()
+	defer r.endScope()
+
+	for _, pattern := range arm.Patterns {
+		if pattern.Kind == ast.PatternBinding && !pattern.IsWildcard() {
+			r.scopes.Peek().(scope)[pattern.Name.Literal] = declVar
+		}
+	}
+
+	if err := r.resolveExpr(arm.Guard); err != nil {
+		return err
+	}
+	return r.resolveStmt(arm.Body)
+}
+
+func (r *resolver) resolveExprs(exprs []ast.Expression) error {
+	for _, expr := range exprs {
+		if err := r.resolveExpr(expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *resolver) resolveExpr(expr ast.Expression) error {
+	switch v := expr.(type) {
+	case *ast.Assign:
+		if err := r.resolveExpr(v.Value); err != nil {
+			return err
+		}
+		return r.assign(v.Name.Literal)
+	case *ast.Logical:
+		return r.resolveExprs([]ast.Expression{v.Left, v.Right})
+	case *ast.Binary:
+		return r.resolveExprs([]ast.Expression{v.Left, v.Right})
+	case *ast.Unary:
+		return r.resolveExpr(v.Right)
+	case *ast.CompoundAssign:
+		if err := r.resolveExprs([]ast.Expression{v.Target, v.Value}); err != nil {
+			return err
+		}
+		return r.assignTarget(v.Target)
+	case *ast.DExp:
+		if err := r.resolveExpr(v.Left); err != nil {
+			return err
+		}
+		return r.assignTarget(v.Left)
+	case *ast.Grouping:
+		return r.resolveExpr(v.Expr)
+	case *ast.Ternary:
+		return r.resolveExprs([]ast.Expression{v.Condition, v.Then, v.Else})
+	case *ast.OptionalChain:
+		return r.resolveExpr(v.Expr)
+	case *ast.Call:
+		if err := r.resolveExpr(v.Callee); err != nil {
+			return err
+		}
+		return r.resolveExprs(v.Arguments)
+	case *ast.Spread:
+		return r.resolveExpr(v.Expr)
+	case *ast.KeywordArg:
+		return r.resolveExpr(v.Value)
+	case *ast.Get:
+		return r.resolveExpr(v.Expr)
+	case *ast.Set:
+		return r.resolveExprs([]ast.Expression{v.Expr, v.Value})
+	case *ast.Slice:
+		return r.resolveExprs(v.Elements)
+	case *ast.MapLiteral:
+		for _, pair := range v.Pairs {
+			if err := r.resolveExprs([]ast.Expression{pair.Key, pair.Value}); err != nil {
+				return err
+			}
+		}
+	case *ast.SliceAccess:
+		return r.resolveExprs([]ast.Expression{v.Name, v.Idx})
+	case *ast.SliceRange:
+		return r.resolveExprs([]ast.Expression{v.Name, v.Low, v.High, v.Step})
+	case *ast.SliceElementAssign:
+		return r.resolveExprs([]ast.Expression{v.SLA, v.Value})
+	case *ast.Match:
+		if err := r.resolveExpr(v.Subject); err != nil {
+			return err
+		}
+		for _, arm := range v.Arms {
+			if err := r.resolveMatchArm(arm); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
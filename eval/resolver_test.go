@@ -0,0 +1,127 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/lexer"
+	"github.com/forfd8960/simpleinterpreter/parser"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name: "shadow in inner scope",
+			input: `
+			let x = 1;
+			const y = 2;
+			fn f(x) {
+				let y = x;
+				y = 3;
+				return y;
+			}
+			if (true) {
+				let x = 2;
+			} else {
+				let x = 3;
+			}
+			`,
+		},
+		{
+			name: "redeclare let",
+			input: `
+			let x = 1;
+			let x = 2;
+			`,
+			wantErr: "identifier: x is already declared",
+		},
+		{
+			name: "redeclare parameter",
+			input: `
+			fn f(a, a) { return a; }
+			`,
+			wantErr: "identifier: a is already declared",
+		},
+		{
+			name: "function can be declared again",
+			input: `
+			fn f() { return 1; }
+			fn f() { return 2; }
+			`,
+		},
+		{
+			name: "function can not replace constant",
+			input: `
+			const f = 1;
+			fn f() { return 2; }
+			`,
+			wantErr: "identifier: f is already declared",
+		},
+		{
+			name: "assign to constant",
+			input: `
+			const x = 1;
+			fn f() {
+				x = 2;
+			}
+			`,
+			wantErr: "can not assign to constant: x",
+		},
+		{
+			name: "assign to constant in nested expression",
+			input: `
+			const x = 1;
+			let xs = [x = 2];
+			`,
+			wantErr: "can not assign to constant: x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.TokensFromInput(tt.input)
+			assert.Nil(t, err)
+
+			program, err := parser.NewParser(tokens).ParseProgram()
+			assert.Nil(t, err)
+
+			err = Resolve(program)
+			if tt.wantErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveTailCall(t *testing.T) {
+	tokenList, err := lexer.TokensFromInput(`
+	fn f(n) {
+		if (n > 0) { return f(n - 1); }
+		return 1 + f(n);
+	}
+	fn gen() { yield 1; return f(1); }
+	return f(1);
+	`)
+	assert.Nil(t, err)
+
+	program, err := parser.NewParser(tokenList).ParseProgram()
+	assert.Nil(t, err)
+	assert.Nil(t, Resolve(program))
+
+	body := program.Stmts[0].(*ast.Function).Body.Statements
+	ifBlock := body[0].(*ast.IFStmt).ThenBranch.(*ast.Block)
+	assert.True(t, ifBlock.Statements[0].(*ast.ReturnStmt).TailCall)
+	assert.False(t, body[1].(*ast.ReturnStmt).TailCall)
+
+	genBody := program.Stmts[1].(*ast.Function).Body.Statements
+	assert.False(t, genBody[1].(*ast.ReturnStmt).TailCall)
+	assert.False(t, program.Stmts[2].(*ast.ReturnStmt).TailCall)
+}
@@ -3,3 +3,39 @@ package eval
 type Stack struct {
 	elements []any
 }
+
+func NewStack() *Stack {
+	return &Stack{}
+}
+
+func (s *Stack) Push(e any) {
+	s.elements = append(s.elements, e)
+}
+
+// Pop removes the top element and returns it, it returns nil if the stack is empty
+func (s *Stack) Pop() any {
+	if len(s.elements) == 0 {
+		return nil
+	}
+
+	e := s.elements[len(s.elements)-1]
+	s.elements = s.elements[:len(s.elements)-1]
+	return e
+}
+
+// Peek returns the top element, it returns nil if the stack is empty
+func (s *Stack) Peek() any {
+	if len(s.elements) == 0 {
+		return nil
+	}
+	return s.elements[len(s.elements)-1]
+}
+
+// At returns the element at idx, idx 0 is the bottom of the stack
+func (s *Stack) At(idx int) any {
+	return s.elements[idx]
+}
+
+func (s *Stack) Len() int {
+	return len(s.elements)
+}
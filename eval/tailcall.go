@@ -0,0 +1,44 @@
+package eval
+
+import (
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+const objTailCall object.ObjectType = "TAIL_CALL"
+
+// tailCall is the call of `return f(...)` in a function, which is returned to callFunctionWith instead of
+// being called in place: the function returns first, so its Go frames are released before f runs
+type tailCall struct {
+	fn     *object.Function
+	args   []object.Object
+	kwargs []keywordArg
+}
+
+func (tc *tailCall) Inspect() string {
+	return "tail call of " + tc.fn.Inspect()
+}
+
+func (tc *tailCall) Type() object.ObjectType {
+	return objTailCall
+}
+
+// evalTailCall evaluates the callee and arguments of call, only the calls of script functions are deferred,
+// builtins and classes are called in place
+func evalTailCall(call *ast.Call, env *object.Environment) (object.Object, error) {
+	callee, err := Eval(call.Callee, env)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := callee.(*object.Function)
+	if !ok {
+		return callObject(callee, call, env)
+	}
+
+	args, kwargs, err := evalArguments(call.Arguments, env)
+	if err != nil {
+		return nil, err
+	}
+	return &tailCall{fn: fn, args: args, kwargs: kwargs}, nil
+}
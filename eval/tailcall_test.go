@@ -0,0 +1,122 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func TestEvalTailCall(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "million-deep countdown",
+			input: `
+			fn countdown(n) {
+				if (n == 0) { return "done"; }
+				return countdown(n - 1);
+			}
+			return countdown(1000000);
+			`,
+			want: &object.String{Value: "done"},
+		},
+		{
+			name: "accumulator",
+			input: `
+			fn sum(n, acc = 0) {
+				if (n == 0) { return acc; }
+				return sum(n - 1, acc: acc + n);
+			}
+			return sum(1000000);
+			`,
+			want: &object.Integer{Value: 500000500000},
+		},
+		{
+			name: "mutual recursion",
+			input: `
+			fn isEven(n) {
+				if (n == 0) { return true; }
+				return isOdd(n - 1);
+			}
+			fn isOdd(n) {
+				if (n == 0) { return false; }
+				return isEven(n - 1);
+			}
+			return [isEven(1000000), isOdd(777777)];
+			`,
+			want: boolSlice(true, true),
+		},
+		{
+			name: "tail call in loop and match",
+			input: `
+			fn find(xs, target, idx) {
+				for (x in xs) {
+					match (x) {
+						_ if x == target => { return idx; }
+						_ => { return find(xs[1:], target, idx + 1); }
+					}
+				}
+				return -1;
+			}
+			return [find([5, 6, 7], 7, 0), find([5, 6, 7], 8, 0)];
+			`,
+			want: intSlice(2, -1),
+		},
+		{
+			name: "method",
+			input: `
+			class Counter {
+				down(n) {
+					if (n == 0) { return this.name; }
+					return this.down(n - 1);
+				}
+			}
+			let c = Counter();
+			c.name = "counter";
+			return c.down(200000);
+			`,
+			want: &object.String{Value: "counter"},
+		},
+		{
+			name: "tail call of builtin and class",
+			input: `
+			class P {}
+			fn size(xs) { return len(xs); }
+			fn make() { return P(); }
+			return [size([1, 2]), str(make())];
+			`,
+			want: &object.Slice{Elements: []object.Object{&object.Integer{Value: 2}, &object.String{Value: "P{}"}}},
+		},
+		{
+			name: "tail call of generator function",
+			input: `
+			fn gen(n) { yield n; }
+			fn start(n) { return gen(n); }
+			return collect(start(3));
+			`,
+			want: intSlice(3),
+		},
+		{
+			name: "error in tail call",
+			input: `
+			fn f(a) { return a; }
+			fn g() { return f(); }
+			g();
+			`,
+			want: &object.Error{Message: "wrong number of arguments to f: got 0, want 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
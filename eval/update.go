@@ -0,0 +1,162 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+var (
+	ErrInvalidAssignTarget = "invalid assignment target: %s"
+)
+
+// the binary operator applied by each compound assignment operator
+var compoundOperators = map[tokens.TokenType]tokens.TokenType{
+	tokens.PLUSEQ:     tokens.PLUS,
+	tokens.MINUSEQ:    tokens.MINUS,
+	tokens.ASTERISKEQ: tokens.ASTERISK,
+	tokens.SLASHEQ:    tokens.SLASH,
+	tokens.DPlus:      tokens.PLUS,
+	tokens.DMinus:     tokens.MINUS,
+}
+
+// evalCompoundAssign evaluates target op= value, and returns the new value of target
+func evalCompoundAssign(ca *ast.CompoundAssign, env *object.Environment) (object.Object, error) {
+	op, ok := compoundOperators[ca.Operator.TkType]
+	if !ok {
+		return nil, fmt.Errorf(ErrNotSupportedOperator, ca.Operator.Literal)
+	}
+
+	_, newValue, err := updateTarget(ca.Target, env, func(old object.Object) (object.Object, error) {
+		value, err := Eval(ca.Value, env)
+		if err != nil {
+			return nil, err
+		}
+		return binaryOp(old, value, op, env)
+	})
+	return newValue, err
+}
+
+// evalDExp evaluates a++, a--, ++a and --a,
+// the postfix form returns the old value of a, and the prefix form returns the new value.
+func evalDExp(dexp *ast.DExp, env *object.Environment) (object.Object, error) {
+	op, ok := compoundOperators[dexp.Operator.TkType]
+	if !ok {
+		return nil, fmt.Errorf(ErrNotSupportedOperator, dexp.Operator.Literal)
+	}
+
+	oldValue, newValue, err := updateTarget(dexp.Left, env, func(old object.Object) (object.Object, error) {
+		return binaryOp(old, &object.Integer{Value: 1}, op, env)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if dexp.Prefix {
+		return newValue, nil
+	}
+	return oldValue, nil
+}
+
+// updateTarget reads the value of target, and stores the value returned by update back to it.
+// the object and index of target are evaluated only once.
+func updateTarget(
+	target ast.Expression,
+	env *object.Environment,
+	update func(old object.Object) (object.Object, error),
+) (object.Object, object.Object, error) {
+	switch v := target.(type) {
+	case *ast.Identifier:
+		old, ok := env.Get(v.Name)
+		if !ok {
+			return nil, nil, fmt.Errorf(ErrIdentifierNotFound, v.Name)
+		}
+
+		value, err := update(old)
+		if err != nil {
+			return nil, nil, err
+		}
+		return old, value, env.Assign(v.Name, value)
+	case *ast.Get:
+		obj, err := Eval(v.Expr, env)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		clsInstance, ok := obj.(*object.ClassInstance)
+		if !ok {
+			return nil, nil, fmt.Errorf("obj: %s is not class instance, only instance has fields", obj.Inspect())
+		}
+
+		old, err := clsInstance.Get(v.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		value, err := update(old)
+		if err != nil {
+			return nil, nil, err
+		}
+		return old, value, clsInstance.Set(v.Name, value)
+	case *ast.SliceAccess:
+		return updateElement(v, env, update)
+	}
+
+	return nil, nil, fmt.Errorf(ErrInvalidAssignTarget, target.TokenLiteral())
+}
+
+func updateElement(
+	sa *ast.SliceAccess,
+	env *object.Environment,
+	update func(old object.Object) (object.Object, error),
+) (object.Object, object.Object, error) {
+	slObj, err := Eval(sa.Name, env)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	idxObj, err := Eval(sa.Idx, env)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if m, isMap := slObj.(*object.Map); isMap {
+		old, ok, err := m.Get(idxObj)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			old = &object.Null{}
+		}
+
+		value, err := update(old)
+		if err != nil {
+			return nil, nil, err
+		}
+		return old, value, m.Set(idxObj, value)
+	}
+
+	sl, ok := slObj.(*object.Slice)
+	if !ok {
+		return nil, nil, fmt.Errorf(ErrIDentIsNotSlice, sa.Name.TokenLiteral())
+	}
+
+	idx, ok := idxObj.(*object.Integer)
+	if !ok {
+		return nil, nil, fmt.Errorf(ErrIdxIsNotInteger, idxObj.Inspect())
+	}
+
+	i, err := normalizeIndex(idx.Value, len(sl.Elements))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	old := sl.Elements[i]
+	value, err := update(old)
+	if err != nil {
+		return nil, nil, err
+	}
+	return old, value, sl.Set(value, i)
+}
@@ -0,0 +1,161 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/object"
+)
+
+func TestEvalCompoundAssign(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  object.Object
+	}{
+		{
+			name: "identifier",
+			input: `
+			let x = 10;
+			x += 5;
+			x -= 3;
+			x *= 2;
+			x /= 4;
+			return x;
+			`,
+			want: &object.Integer{Value: 6},
+		},
+		{
+			name: "compound assignment returns the new value",
+			input: `
+			let s = "a";
+			let t = s += "b";
+			return [s, t];
+			`,
+			want: &object.Slice{Elements: []object.Object{
+				&object.String{Value: "ab"},
+				&object.String{Value: "ab"},
+			}},
+		},
+		{
+			name: "instance field",
+			input: `
+			class Counter {}
+			let c = Counter();
+			c.n = 1;
+			c.n += 41;
+			return c.n;
+			`,
+			want: &object.Integer{Value: 42},
+		},
+		{
+			name: "slice element and map value",
+			input: `
+			let xs = [1, 2, 3];
+			xs[-1] *= 10;
+			let m = {"a": 1};
+			m["a"] += 1;
+			return [xs[2], m["a"]];
+			`,
+			want: intSlice(30, 2),
+		},
+		{
+			name: "index is evaluated once",
+			input: `
+			let calls = 0;
+			fn idx() { calls += 1; return 0; }
+			let xs = [1];
+			xs[idx()] += 1;
+			return [xs[0], calls];
+			`,
+			want: intSlice(2, 1),
+		},
+		{
+			name: "postfix returns the old value",
+			input: `
+			let i = 1;
+			let a = i++;
+			let b = i--;
+			return [a, b, i];
+			`,
+			want: intSlice(1, 2, 1),
+		},
+		{
+			name: "prefix returns the new value",
+			input: `
+			let i = 1;
+			let a = ++i;
+			let b = --i;
+			return [a, b, i];
+			`,
+			want: intSlice(2, 1, 1),
+		},
+		{
+			name: "increment field and element",
+			input: `
+			class Counter {}
+			let c = Counter();
+			c.n = 0;
+			c.n++;
+			++c.n;
+			let xs = [5];
+			xs[0]--;
+			return [c.n, xs[0]];
+			`,
+			want: intSlice(2, 4),
+		},
+		{
+			name: "increment in while loop",
+			input: `
+			let i = 0;
+			let sum = 0;
+			while (i < 5) {
+				sum += i;
+				i++;
+			}
+			return sum;
+			`,
+			want: &object.Integer{Value: 10},
+		},
+		{
+			name: "compound assignment to constant",
+			input: `
+			const x = 1;
+			x += 1;
+			`,
+			want: &object.Error{Message: "can not assign to constant: x"},
+		},
+		{
+			name: "increment constant",
+			input: `
+			const x = 1;
+			x++;
+			`,
+			want: &object.Error{Message: "can not assign to constant: x"},
+		},
+		{
+			name: "undefined identifier",
+			input: `
+			y += 1;
+			`,
+			want: &object.Error{Message: "identifier: y is not found"},
+		},
+		{
+			name: "frozen slice",
+			input: `
+			let xs = freeze([1]);
+			xs[0] += 1;
+			`,
+			want: &object.Error{Message: "can not modify frozen slice: [1]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := testEvalInput(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, obj)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Package format rewrites scripts in the canonical form printed by ast.Format
+package format
+
+import (
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/lexer"
+	"github.com/forfd8960/simpleinterpreter/parser"
+)
+
+// Source returns the formatted src, formatting the result again returns it unchanged
+func Source(src []byte) ([]byte, error) {
+	program, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(ast.Format(program)), nil
+}
+
+func parse(src []byte) (*ast.Program, error) {
+	tokenList, err := lexer.TokensFromInput(string(src))
+	if err != nil {
+		return nil, err
+	}
+	return parser.NewParser(tokenList).ParseProgram()
+}
@@ -0,0 +1,142 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "spacing",
+			input: `let x=1+2*(3-4);const Y=-x;x+=1;y=x>1?"a":"b";`,
+			want:  "let x = 1 + 2 * (3 - 4);\nconst Y = -x;\nx += 1;\ny = x > 1 ? \"a\" : \"b\";\n",
+		},
+		{
+			name:  "function",
+			input: `fn add(a,b=1,...rest){if(a>b){return a+b;}else if(a==b){return;}else return -a;}add(1,...xs,b:2);`,
+			want: `fn add(a, b = 1, ...rest) {
+	if (a > b) {
+		return a + b;
+	} else if (a == b) {
+		return;
+	} else return -a;
+}
+
+add(1, ...xs, b: 2);
+`,
+		},
+		{
+			name:  "class keeps the order of methods",
+			input: `class P{zero(){return 0;}add(x){this.v+=x; return this;}} class E{}`,
+			want: `class P {
+	zero() {
+		return 0;
+	}
+
+	add(x) {
+		this.v += x;
+		return this;
+	}
+}
+
+class E {}
+`,
+		},
+		{
+			name:  "loops",
+			input: `for(let i=0;i<3;i++){print("%d\n",i);} for(;;)break; for(k,v in m){} while(x){x--;}`,
+			want: `for (let i = 0; i < 3; i++) {
+	print("%d\n", i);
+}
+for (;;) break;
+for (k, v in m) {}
+while (x) {
+	x--;
+}
+`,
+		},
+		{
+			name:  "collections",
+			input: `let m={"a":[1,2.50,null],"b\t":{}};m["a"][0]=xs[1:][::2][:-1][0:1:2];`,
+			want:  "let m = {\"a\": [1, 2.50, null], \"b\\t\": {}};\nm[\"a\"][0] = xs[1:][::2][:-1][0:1:2];\n",
+		},
+		{
+			name:  "match",
+			input: `let s=match(x){1,2=>"small",3..=10 if x>4=>{return x;}-5..0=>"neg",_=>"large"}; match(x){n=>n}`,
+			want: `let s = match (x) {
+	1, 2 => "small",
+	3..=10 if x > 4 => {
+		return x;
+	}
+	-5..0 => "neg",
+	_ => "large",
+};
+match (x) {
+	n => n,
+}
+`,
+		},
+		{
+			name:  "generator and chains",
+			input: `fn gen(){yield a?.b.c()??d; yield;} let y = - -x; let z = - --x; let w = !!x && y || z;`,
+			want: `fn gen() {
+	yield a?.b.c() ?? d;
+	yield;
+}
+
+let y = - -x;
+let z = - --x;
+let w = !!x && y || z;
+`,
+		},
+		{
+			name:  "modules",
+			input: `import "lib.si" as lib;import "b.si";from "c.si" import a,b;export fn f(){} export let v=1;`,
+			want: `import "lib.si" as lib;
+import "b.si";
+from "c.si" import a, b;
+
+export fn f() {}
+
+export let v = 1;
+`,
+		},
+		{
+			name:  "empty",
+			input: ``,
+			want:  ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Source([]byte(tt.input))
+			if !assert.Nil(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, string(out))
+
+			// the formatted source has the same tree, and formatting it again changes nothing
+			want, err := parse([]byte(tt.input))
+			assert.Nil(t, err)
+			got, err := parse(out)
+			assert.Nil(t, err)
+			assert.Equal(t, want, got)
+
+			again, err := Source(out)
+			assert.Nil(t, err)
+			assert.Equal(t, string(out), string(again))
+		})
+	}
+}
+
+func TestSourceError(t *testing.T) {
+	_, err := Source([]byte(`let = 1;`))
+	assert.EqualError(t, err, "expect identifier name")
+}
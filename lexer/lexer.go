@@ -3,6 +3,7 @@ package lexer
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"unicode"
 
 	"github.com/forfd8960/simpleinterpreter/tokens"
@@ -11,7 +12,15 @@ import (
 var (
 	ErrUnSupportedToken = "unsupported token: %v"
 	ErrInvalidString    = "invalid string: %s"
-	whiteSpace          = map[rune]struct{}{
+	ErrInvalidEscape    = "invalid escape sequence: %s"
+	escapes             = map[rune]rune{
+		'"':  '"',
+		'\\': '\\',
+		'n':  '\n',
+		't':  '\t',
+		'r':  '\r',
+	}
+	whiteSpace = map[rune]struct{}{
 		' ':  {},
 		'\n': {},
 		'\r': {},
@@ -24,12 +33,17 @@ type Lexer struct {
 	runes   []rune
 	start   int // current index in input
 	current int // current read index in input after pos
+
+	// pos is the position of runes[scanned], Position moves them forward to start
+	pos     tokens.Position
+	scanned int
 }
 
 func NewLexer(input string) *Lexer {
 	return &Lexer{
 		input: input,
 		runes: []rune(input),
+		pos:   tokens.Position{Line: 1, Column: 1},
 	}
 }
 
@@ -55,12 +69,34 @@ func TokensFromInput(input string) ([]*tokens.Token, error) {
 	return tokenList, nil
 }
 
+// Position returns where the last token returned by NextToken starts, or where the token which fails to scan starts
+func (l *Lexer) Position() tokens.Position {
+	for ; l.scanned < l.start && l.scanned < len(l.runes); l.scanned++ {
+		if l.runes[l.scanned] == '\n' {
+			l.pos.Line++
+			l.pos.Column = 1
+		} else {
+			l.pos.Column++
+		}
+	}
+	return l.pos
+}
+
+// NextToken returns the next token in the input, whitespaces are skipped
 func (l *Lexer) NextToken() (*tokens.Token, error) {
-	if !l.isAtEnd() {
+	for !l.isAtEnd() {
 		l.start = l.current
-		return l.scanToken()
+		tk, err := l.scanToken()
+		if err != nil {
+			return nil, err
+		}
+
+		if tk.TkType != tokens.WS {
+			return tk, nil
+		}
 	}
 
+	l.start = l.current
 	return newEOFToken(), nil
 }
 
@@ -76,25 +112,78 @@ func (l *Lexer) scanToken() (*tokens.Token, error) {
 	case ' ', '\n', '\r', '\t':
 		return l.buildToken(tokens.WS, "whitespace"), nil
 	case '=':
-		tok = CondExp(l.match('='), l.buildToken(tokens.EQUAL, "=="), l.buildToken(tokens.ASSIGN, "="))
+		switch {
+		case l.match('='):
+			tok = l.buildToken(tokens.EQUAL, "==")
+		case l.match('>'):
+			tok = l.buildToken(tokens.ARROW, "=>")
+		default:
+			tok = l.buildToken(tokens.ASSIGN, "=")
+		}
 	case ';':
 		tok = l.buildToken(tokens.SEMICOLON, ";")
 	case ',':
 		tok = l.buildToken(tokens.COMMA, ",")
+	case ':':
+		tok = l.buildToken(tokens.COLON, ":")
 	case '+':
-		tok = CondExp(l.match('+'), l.buildToken(tokens.DPlus, "++"), l.buildToken(tokens.PLUS, "+"))
+		switch {
+		case l.match('+'):
+			tok = l.buildToken(tokens.DPlus, "++")
+		case l.match('='):
+			tok = l.buildToken(tokens.PLUSEQ, "+=")
+		default:
+			tok = l.buildToken(tokens.PLUS, "+")
+		}
 	case '-':
-		tok = CondExp(l.match('-'), l.buildToken(tokens.DMinus, "--"), l.buildToken(tokens.MINUS, "-"))
+		switch {
+		case l.match('-'):
+			tok = l.buildToken(tokens.DMinus, "--")
+		case l.match('='):
+			tok = l.buildToken(tokens.MINUSEQ, "-=")
+		default:
+			tok = l.buildToken(tokens.MINUS, "-")
+		}
 	case '*':
-		tok = CondExp(l.match('*'), l.buildToken(tokens.POW, "**"), l.buildToken(tokens.ASTERISK, "*"))
+		switch {
+		case l.match('*'):
+			tok = l.buildToken(tokens.POW, "**")
+		case l.match('='):
+			tok = l.buildToken(tokens.ASTERISKEQ, "*=")
+		default:
+			tok = l.buildToken(tokens.ASTERISK, "*")
+		}
 	case '/':
-		tok = l.buildToken(tokens.SLASH, "/")
+		tok = CondExp(l.match('='), l.buildToken(tokens.SLASHEQ, "/="), l.buildToken(tokens.SLASH, "/"))
+	case '%':
+		tok = l.buildToken(tokens.PERCENT, "%")
 	case '!':
 		tok = CondExp(l.match('='), l.buildToken(tokens.NOTEQUAL, "!="), l.buildToken(tokens.BANG, "!"))
 	case '<':
 		tok = CondExp(l.match('='), l.buildToken(tokens.LTEQ, "<="), l.buildToken(tokens.LT, "<"))
 	case '>':
 		tok = CondExp(l.match('='), l.buildToken(tokens.GTEQ, ">="), l.buildToken(tokens.GT, ">"))
+	case '&':
+		if !l.match('&') {
+			err = fmt.Errorf(ErrUnSupportedToken, string(r))
+			break
+		}
+		tok = l.buildToken(tokens.AND, "&&")
+	case '|':
+		if !l.match('|') {
+			err = fmt.Errorf(ErrUnSupportedToken, string(r))
+			break
+		}
+		tok = l.buildToken(tokens.OR, "||")
+	case '?':
+		switch {
+		case l.match('?'):
+			tok = l.buildToken(tokens.DQUESTION, "??")
+		case l.match('.'):
+			tok = l.buildToken(tokens.QDOT, "?.")
+		default:
+			tok = l.buildToken(tokens.QUESTION, "?")
+		}
 	case '(':
 		tok = l.buildToken(tokens.LPRARENT, "(")
 	case ')':
@@ -110,10 +199,18 @@ func (l *Lexer) scanToken() (*tokens.Token, error) {
 	case '"':
 		tok, err = l.parseString()
 	case '.':
-		tok = l.buildToken(tokens.DOT, ".")
+		if l.match('.') {
+			if l.match('.') {
+				tok = l.buildToken(tokens.ELLIPSIS, "...")
+			} else {
+				tok = CondExp(l.match('='), l.buildToken(tokens.DOTDOTEQ, "..="), l.buildToken(tokens.DOTDOT, ".."))
+			}
+		} else {
+			tok = l.buildToken(tokens.DOT, ".")
+		}
 	default:
 		if isDigit(r) {
-			tok, err = l.parseInteger()
+			tok, err = l.parseNumber()
 		} else if isAlpha(r) {
 			tok = l.parseIdent()
 		} else {
@@ -149,11 +246,26 @@ func (l *Lexer) advance() rune {
 	return l.runes[l.current-1]
 }
 
-func (l *Lexer) parseInteger() (*tokens.Token, error) {
+// parseNumber parse integer: 123 and float: 1.23
+func (l *Lexer) parseNumber() (*tokens.Token, error) {
 	for isDigit(l.peek()) {
 		l.advance()
 	}
 
+	if l.peek() == '.' && isDigit(l.peekNext()) {
+		l.advance() // skip .
+		for isDigit(l.peek()) {
+			l.advance()
+		}
+
+		text := string(l.runes[l.start:l.current])
+		num, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, err
+		}
+		return tokens.NewToken(tokens.FLOAT, text, num), nil
+	}
+
 	text := string(l.runes[l.start:l.current])
 	num, err := strconv.ParseInt(text, 10, 64)
 	if err != nil {
@@ -163,30 +275,34 @@ func (l *Lexer) parseInteger() (*tokens.Token, error) {
 	return tokens.NewToken(tokens.INTEGER, text, num), nil
 }
 
+// parseString parse "..." and decodes the escape sequences: \" \\ \n \t \r
 func (l *Lexer) parseString() (*tokens.Token, error) {
-	l.advance() // skip "
-
-	var isEnd bool
-	for l.peek() != '"' {
-		l.advance()
+	sb := &strings.Builder{}
+	for {
 		if l.isAtEnd() {
-			isEnd = true
-			break
+			return nil, fmt.Errorf(ErrInvalidString, string(l.runes[l.start:]))
 		}
-	}
 
-	var val string
-	if isEnd {
-		if l.peek() != '"' {
-			return nil, fmt.Errorf(ErrInvalidString, string(l.runes[l.start:]))
+		r := l.advance()
+		if r == '"' {
+			break
 		}
 
-		val = string(l.runes[l.start+1 : l.current-1])
-	} else {
-		val = string(l.runes[l.start+1 : l.current])
-		l.advance()
+		if r == '\\' {
+			if l.isAtEnd() {
+				return nil, fmt.Errorf(ErrInvalidString, string(l.runes[l.start:]))
+			}
+
+			escaped, ok := escapes[l.advance()]
+			if !ok {
+				return nil, fmt.Errorf(ErrInvalidEscape, string(l.runes[l.current-2:l.current]))
+			}
+			r = escaped
+		}
+		sb.WriteRune(r)
 	}
 
+	val := sb.String()
 	return tokens.NewToken(tokens.STRING, val, val), nil
 }
 
@@ -196,13 +312,13 @@ func (l *Lexer) parseIdent() *tokens.Token {
 	}
 
 	ident := string(l.runes[l.start:l.current])
-	tkType := tokens.LookupIdent(ident)
-	tk := tokens.LookupTokenByIdent(ident)
-	if tk == nil {
-		tk = tokens.NewToken(tkType, ident, ident)
+	if kw := tokens.LookupTokenByIdent(ident); kw != nil {
+		// every token has its own pointer, so the tools can find where a keyword is by its token
+		tk := *kw
+		return &tk
 	}
 
-	return tk
+	return tokens.NewToken(tokens.LookupIdent(ident), ident, ident)
 }
 
 func (l *Lexer) match(r rune) bool {
@@ -226,6 +342,14 @@ func (l *Lexer) peek() rune {
 	return l.runes[l.current]
 }
 
+func (l *Lexer) peekNext() rune {
+	if l.current+1 >= len(l.runes) {
+		return '\000'
+	}
+
+	return l.runes[l.current+1]
+}
+
 func isDigit(r rune) bool {
 	return unicode.IsDigit(r)
 }
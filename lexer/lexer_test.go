@@ -1,6 +1,7 @@
 package lexer
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -37,6 +38,28 @@ func TestNextToken(t *testing.T) {
 	}
 }
 
+func TestNextTokenSkipsWhitespace(t *testing.T) {
+	input := " \t\n a \r\n  ;  "
+	tests := []struct {
+		expectType tokens.TokenType
+		literal    string
+	}{
+		{tokens.IDENT, "a"},
+		{tokens.SEMICOLON, ";"},
+		{tokens.EOF, tokens.LiteralEOF},
+		{tokens.EOF, tokens.LiteralEOF},
+	}
+
+	lexer := NewLexer(input)
+
+	for _, tt := range tests {
+		token, err := lexer.NextToken()
+		assert.Nil(t, err)
+		assert.Equal(t, tt.expectType, token.TkType)
+		assert.Equal(t, tt.literal, token.Literal)
+	}
+}
+
 func TestLexerLetStmt(t *testing.T) {
 	input := "let x = 5;"
 	tests := []struct {
@@ -295,3 +318,249 @@ func TestLexerDPlusDMinus(t *testing.T) {
 		})
 	}
 }
+
+func TestLexerNumber(t *testing.T) {
+	input := `10 % 3 1.25 xs.len 7.`
+	lexer := NewLexer(input)
+
+	tests := []struct {
+		expectType tokens.TokenType
+		literal    string
+		value      interface{}
+	}{
+		{tokens.INTEGER, "10", int64(10)},
+		{tokens.PERCENT, "%", "%"},
+		{tokens.INTEGER, "3", int64(3)},
+		{tokens.FLOAT, "1.25", 1.25},
+		{tokens.IDENT, "xs", "xs"},
+		{tokens.DOT, ".", "."},
+		{tokens.IDENT, "len", "len"},
+		{tokens.INTEGER, "7", int64(7)},
+		{tokens.DOT, ".", "."},
+		{tokens.EOF, tokens.LiteralEOF, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run("test-"+tt.literal, func(t *testing.T) {
+			token, err := lexer.NextToken()
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectType, token.TkType)
+			assert.Equal(t, tt.literal, token.Literal)
+			assert.Equal(t, tt.value, token.Value)
+		})
+	}
+}
+
+func TestLexerStringEscape(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		value   interface{}
+		wantErr string
+	}{
+		{name: "quote and backslash", input: `"a\"b\\c"`, value: `a"b\c`},
+		{name: "whitespace", input: `"\n\t\r"`, value: "\n\t\r"},
+		{name: "invalid escape", input: `"\x"`, wantErr: `invalid escape sequence: \x`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := NewLexer(tt.input).NextToken()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tokens.STRING, token.TkType)
+			assert.Equal(t, tt.value, token.Value)
+		})
+	}
+}
+
+func TestLexerRangeAndArrow(t *testing.T) {
+	input := `1..5 1..=5 x => y == z`
+	lexer := NewLexer(input)
+
+	tests := []struct {
+		expectType tokens.TokenType
+		literal    string
+	}{
+		{tokens.INTEGER, "1"},
+		{tokens.DOTDOT, ".."},
+		{tokens.INTEGER, "5"},
+		{tokens.INTEGER, "1"},
+		{tokens.DOTDOTEQ, "..="},
+		{tokens.INTEGER, "5"},
+		{tokens.IDENT, "x"},
+		{tokens.ARROW, "=>"},
+		{tokens.IDENT, "y"},
+		{tokens.EQUAL, "=="},
+		{tokens.IDENT, "z"},
+		{tokens.EOF, tokens.LiteralEOF},
+	}
+
+	for _, tt := range tests {
+		t.Run("test-"+tt.literal, func(t *testing.T) {
+			token, err := lexer.NextToken()
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectType, token.TkType)
+			assert.Equal(t, tt.literal, token.Literal)
+		})
+	}
+}
+
+func TestLexerEllipsis(t *testing.T) {
+	input := `f(...xs) ..`
+	lexer := NewLexer(input)
+
+	tests := []struct {
+		expectType tokens.TokenType
+		literal    string
+	}{
+		{tokens.IDENT, "f"},
+		{tokens.LPRARENT, "("},
+		{tokens.ELLIPSIS, "..."},
+		{tokens.IDENT, "xs"},
+		{tokens.RPARENT, ")"},
+		{tokens.DOTDOT, ".."},
+		{tokens.EOF, tokens.LiteralEOF},
+	}
+
+	for _, tt := range tests {
+		t.Run("test-"+tt.literal, func(t *testing.T) {
+			token, err := lexer.NextToken()
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectType, token.TkType)
+			assert.Equal(t, tt.literal, token.Literal)
+		})
+	}
+}
+
+func TestLexerQuestion(t *testing.T) {
+	input := `a ? b : c ?? d?.e`
+	lexer := NewLexer(input)
+
+	tests := []struct {
+		expectType tokens.TokenType
+		literal    string
+	}{
+		{tokens.IDENT, "a"},
+		{tokens.QUESTION, "?"},
+		{tokens.IDENT, "b"},
+		{tokens.COLON, ":"},
+		{tokens.IDENT, "c"},
+		{tokens.DQUESTION, "??"},
+		{tokens.IDENT, "d"},
+		{tokens.QDOT, "?."},
+		{tokens.IDENT, "e"},
+		{tokens.EOF, tokens.LiteralEOF},
+	}
+
+	for _, tt := range tests {
+		t.Run("test-"+tt.literal, func(t *testing.T) {
+			token, err := lexer.NextToken()
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectType, token.TkType)
+			assert.Equal(t, tt.literal, token.Literal)
+		})
+	}
+}
+
+func TestLexerCompoundAssign(t *testing.T) {
+	input := `a += 1 -= 2 *= 3 /= 4 ++b --c ** d`
+	lexer := NewLexer(input)
+
+	tests := []struct {
+		expectType tokens.TokenType
+		literal    string
+	}{
+		{tokens.IDENT, "a"},
+		{tokens.PLUSEQ, "+="},
+		{tokens.INTEGER, "1"},
+		{tokens.MINUSEQ, "-="},
+		{tokens.INTEGER, "2"},
+		{tokens.ASTERISKEQ, "*="},
+		{tokens.INTEGER, "3"},
+		{tokens.SLASHEQ, "/="},
+		{tokens.INTEGER, "4"},
+		{tokens.DPlus, "++"},
+		{tokens.IDENT, "b"},
+		{tokens.DMinus, "--"},
+		{tokens.IDENT, "c"},
+		{tokens.POW, "**"},
+		{tokens.IDENT, "d"},
+		{tokens.EOF, tokens.LiteralEOF},
+	}
+
+	for _, tt := range tests {
+		t.Run("test-"+tt.literal, func(t *testing.T) {
+			token, err := lexer.NextToken()
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectType, token.TkType)
+			assert.Equal(t, tt.literal, token.Literal)
+		})
+	}
+}
+
+func TestLexerLogical(t *testing.T) {
+	tokenList, err := TokensFromInput(`a && b || c`)
+	if assert.Nil(t, err) && assert.Equal(t, 6, len(tokenList)) {
+		assert.Equal(t, tokens.AND, tokenList[1].TkType)
+		assert.Equal(t, "&&", tokenList[1].Literal)
+		assert.Equal(t, tokens.OR, tokenList[3].TkType)
+		assert.Equal(t, "||", tokenList[3].Literal)
+	}
+
+	_, err = TokensFromInput(`a & b`)
+	assert.EqualError(t, err, fmt.Sprintf(ErrUnSupportedToken, "&"))
+
+	_, err = TokensFromInput(`a | b`)
+	assert.EqualError(t, err, fmt.Sprintf(ErrUnSupportedToken, "|"))
+}
+
+func TestLexerPositions(t *testing.T) {
+	input := "let x = 1;\n  print \"héllo\" + x;\n"
+	lexer := NewLexer(input)
+
+	tests := []struct {
+		literal  string
+		position tokens.Position
+	}{
+		{"let", tokens.Position{Line: 1, Column: 1}},
+		{"x", tokens.Position{Line: 1, Column: 5}},
+		{"=", tokens.Position{Line: 1, Column: 7}},
+		{"1", tokens.Position{Line: 1, Column: 9}},
+		{";", tokens.Position{Line: 1, Column: 10}},
+		{"print", tokens.Position{Line: 2, Column: 3}},
+		{"héllo", tokens.Position{Line: 2, Column: 9}},
+		{"+", tokens.Position{Line: 2, Column: 17}},
+		{"x", tokens.Position{Line: 2, Column: 19}},
+		{";", tokens.Position{Line: 2, Column: 20}},
+		{tokens.LiteralEOF, tokens.Position{Line: 3, Column: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run("test-"+tt.literal, func(t *testing.T) {
+			token, err := lexer.NextToken()
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.literal, token.Literal)
+			assert.Equal(t, tt.position, lexer.Position())
+		})
+	}
+
+	lexer = NewLexer("let a = 1;\nb & c")
+	for {
+		if _, err := lexer.NextToken(); err != nil {
+			assert.EqualError(t, err, fmt.Sprintf(ErrUnSupportedToken, "&"))
+			break
+		}
+	}
+	assert.Equal(t, tokens.Position{Line: 2, Column: 3}, lexer.Position())
+}
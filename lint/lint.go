@@ -0,0 +1,186 @@
+// Package lint reports the common mistakes in scripts without running them
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/lexer"
+	"github.com/forfd8960/simpleinterpreter/parser"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+const (
+	ErrUnknownRule     = "unknown lint rule: %s"
+	ErrUnknownSeverity = "unknown severity: %s"
+	ErrInvalidSeverity = "invalid severity setting: %s, want rule=severity"
+)
+
+type Severity int
+
+const (
+	SeverityOff Severity = iota // the rule is disabled
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+)
+
+var severityNames = []string{
+	SeverityOff:     "off",
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+func (s Severity) String() string {
+	if s >= 0 && int(s) < len(severityNames) {
+		return severityNames[s]
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func ParseSeverity(name string) (Severity, error) {
+	for s, n := range severityNames {
+		if n == name {
+			return Severity(s), nil
+		}
+	}
+	return SeverityOff, fmt.Errorf(ErrUnknownSeverity, name)
+}
+
+// the rules
+const (
+	RuleSyntax            = "syntax"              // the script can not be lexed or parsed, it is always an error
+	RuleUnusedVariable    = "unused-variable"     // a local let or const is never read
+	RuleShadowedName      = "shadowed-name"       // a declaration hides a name declared in an outer scope
+	RuleUnreachableCode   = "unreachable-code"    // the statements after return
+	RuleNotCallable       = "not-callable"        // a literal is called, like 1() or "f"()
+	RuleWrongArity        = "wrong-arity"         // the arguments do not match the parameters of a function declared in the script
+	RuleThisOutsideMethod = "this-outside-method" // this is used out of the methods of classes
+	RuleUndeclaredAssign  = "undeclared-assign"   // a name which is not declared is assigned
+)
+
+// Rules are the rules which can be configured and their default severities
+var Rules = map[string]Severity{
+	RuleUnusedVariable:    SeverityWarning,
+	RuleShadowedName:      SeverityWarning,
+	RuleUnreachableCode:   SeverityWarning,
+	RuleNotCallable:       SeverityError,
+	RuleWrongArity:        SeverityError,
+	RuleThisOutsideMethod: SeverityError,
+	RuleUndeclaredAssign:  SeverityError,
+}
+
+// Config configures the linter, the zero value uses the default severities
+type Config struct {
+	// Severities overrides the severities of the rules, SeverityOff disables a rule
+	Severities map[string]Severity
+}
+
+func (cfg *Config) severity(rule string) Severity {
+	if rule == RuleSyntax {
+		return SeverityError
+	}
+	if cfg != nil {
+		if s, ok := cfg.Severities[rule]; ok {
+			return s
+		}
+	}
+	return Rules[rule]
+}
+
+// ParseSeverities parses the comma separated settings like "unused-variable=off,shadowed-name=error"
+func ParseSeverities(settings string) (map[string]Severity, error) {
+	severities := map[string]Severity{}
+	if settings == "" {
+		return severities, nil
+	}
+
+	for _, setting := range strings.Split(settings, ",") {
+		rule, name, ok := strings.Cut(strings.TrimSpace(setting), "=")
+		if !ok {
+			return nil, fmt.Errorf(ErrInvalidSeverity, setting)
+		}
+		if _, known := Rules[rule]; !known {
+			return nil, fmt.Errorf(ErrUnknownRule, rule)
+		}
+
+		s, err := ParseSeverity(name)
+		if err != nil {
+			return nil, err
+		}
+		severities[rule] = s
+	}
+	return severities, nil
+}
+
+// Diagnostic is a mistake found by a rule, the position is 0:0 if it is not known
+type Diagnostic struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// String returns the diagnostic like file:1:5: warning: x is declared but never used (unused-variable)
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s (%s)", d.File, d.Line, d.Column, d.Severity, d.Message, d.Rule)
+}
+
+// Source lints the script src read from file, the diagnostics are sorted by their positions
+func Source(file string, src []byte, cfg *Config) []Diagnostic {
+	l := &linter{file: file, cfg: cfg, positions: map[*tokens.Token]tokens.Position{}}
+
+	program, err := l.parse(string(src))
+	if err != nil {
+		return l.diagnostics
+	}
+
+	l.program(program)
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		a, b := l.diagnostics[i], l.diagnostics[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return l.diagnostics
+}
+
+// parse parses src and keeps where the tokens are
+func (l *linter) parse(src string) (*ast.Program, error) {
+	lex := lexer.NewLexer(src)
+	var tokenList []*tokens.Token
+	for {
+		tk, err := lex.NextToken()
+		if err != nil {
+			l.reportAt(lex.Position(), RuleSyntax, err.Error())
+			return nil, err
+		}
+		if tk.TkType == tokens.WS {
+			continue
+		}
+
+		tokenList = append(tokenList, tk)
+		l.positions[tk] = lex.Position()
+		if tk.TkType == tokens.EOF {
+			break
+		}
+	}
+
+	p := parser.NewParser(tokenList)
+	program, err := p.ParseProgram()
+	if err != nil {
+		l.reportAt(l.positions[p.Token()], RuleSyntax, err.Error())
+		return nil, err
+	}
+	return program, nil
+}
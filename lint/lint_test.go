@@ -0,0 +1,176 @@
+package lint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func lintStrings(input string, cfg *Config) []string {
+	var got []string
+	for _, d := range Source("test.si", []byte(input), cfg) {
+		got = append(got, d.String())
+	}
+	return got
+}
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "clean script",
+			input: "let x = 1;\nfn f(a, b = 2) { let s = a + b; return s; }\nprint(f(x), f(a: 1));\n",
+		},
+		{
+			name:  "syntax error of lexer",
+			input: "let x = 1;\nlet y = @;\n",
+			want:  []string{"test.si:2:9: error: unsupported token: @ (syntax)"},
+		},
+		{
+			name:  "syntax error of parser",
+			input: "let x = 1;\nlet = 2;\n",
+			want:  []string{"test.si:2:5: error: expect identifier name (syntax)"},
+		},
+		{
+			name:  "unused variable",
+			input: "let top = 1;\nfn f(p) {\n  let x = 1;\n  let _y = 2;\n  const z = 3;\n  return z;\n}\n",
+			want:  []string{"test.si:3:7: warning: x is declared but never used (unused-variable)"},
+		},
+		{
+			name:  "shadowed name",
+			input: "let x = 1;\nfn f(x) {\n  { let x = 2; print(x); }\n  for (let i = 0; i < x; i++) { let i = 1; print(i); }\n}\n",
+			want: []string{
+				"test.si:2:6: warning: x shadows the declaration at 1:5 (shadowed-name)",
+				"test.si:3:9: warning: x shadows the declaration at 2:6 (shadowed-name)",
+				"test.si:4:37: warning: i shadows the declaration at 4:12 (shadowed-name)",
+			},
+		},
+		{
+			name:  "unreachable code",
+			input: "fn f(x) {\n  if (x) { return 1; } else { return 2; }\n  x = 3;\n  print(x);\n}\nfn g() {\n  return;\n}\n",
+			want:  []string{"test.si:3:3: warning: unreachable code after return (unreachable-code)"},
+		},
+		{
+			name:  "not callable",
+			input: "1();\n(\"f\")(1);\nlet xs = [1](0);\nlet f = 1;\nf();\n",
+			want: []string{
+				"test.si:1:1: error: 1 is not callable (not-callable)",
+				"test.si:2:2: error: \"f\" is not callable (not-callable)",
+				"test.si:3:11: error: [1] is not callable (not-callable)",
+			},
+		},
+		{
+			name: "wrong arity",
+			input: "fn f(a, b = 1) { return a + b; }\nf();\nf(1, 2, 3);\nf(1, c: 2);\nf(1, a: 2);\nf(b: 2);\n" +
+				"f(...[1, 2, 3]);\nfn g(a, ...rest) { return rest; }\ng();\ng(1, 2, 3);\n",
+			want: []string{
+				"test.si:2:1: error: wrong number of arguments to f: got 0, want 1 to 2 (wrong-arity)",
+				"test.si:3:1: error: wrong number of arguments to f: got 3, want 1 to 2 (wrong-arity)",
+				"test.si:4:6: error: f got an unexpected keyword argument: c (wrong-arity)",
+				"test.si:5:6: error: f got multiple values for argument: a (wrong-arity)",
+				"test.si:6:1: error: f missing arguments: a (wrong-arity)",
+				"test.si:9:1: error: wrong number of arguments to g: got 0, want at least 1 (wrong-arity)",
+			},
+		},
+		{
+			name:  "functions which are reassigned are not checked",
+			input: "fn f(a) { return a; }\nf = len;\nf(1, 2);\n",
+		},
+		{
+			name:  "this outside method",
+			input: "class P {\n  get() { fn inner() { return this; } return inner(); }\n}\nfn f() { return this; }\n",
+			want:  []string{"test.si:4:17: error: this can only be used in the methods of classes (this-outside-method)"},
+		},
+		{
+			name:  "undeclared assign",
+			input: "fn f() {\n  y = 1;\n  y = 2;\n  return y;\n}\nz += 1;\nw++;\n",
+			want: []string{
+				"test.si:2:3: error: y is assigned before it is declared, the assignment declares it in the enclosing function or the top level (undeclared-assign)",
+				"test.si:6:1: error: z is updated but it is not declared (undeclared-assign)",
+				"test.si:7:1: error: w is updated but it is not declared (undeclared-assign)",
+			},
+		},
+		{
+			name:  "undeclared assign in block",
+			input: "fn f(x) {\n  if (x) { y = 1; } else { y = 2; }\n  return y;\n}\n",
+			want: []string{
+				"test.si:2:12: error: y is assigned before it is declared, the assignment declares it in the enclosing function or the top level (undeclared-assign)",
+			},
+		},
+		{
+			name:  "match bindings",
+			input: "fn f(x) {\n  return match (x) { n if n > 1 => n, x if x < 0 => 0, _ => 1 };\n}\n",
+			want:  []string{"test.si:2:39: warning: x shadows the declaration at 1:6 (shadowed-name)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, lintStrings(tt.input, nil))
+		})
+	}
+}
+
+func TestSourceSeverities(t *testing.T) {
+	input := "fn f() {\n  let x = 1;\n  return 1;\n  print(2);\n}\n"
+
+	cfg := &Config{Severities: map[string]Severity{RuleUnusedVariable: SeverityOff, RuleUnreachableCode: SeverityError}}
+	assert.Equal(t, []string{"test.si:4:9: error: unreachable code after return (unreachable-code)"}, lintStrings(input, cfg))
+
+	// the syntax errors can not be disabled
+	cfg = &Config{Severities: map[string]Severity{RuleSyntax: SeverityOff}}
+	assert.Equal(t, []string{"test.si:1:4: error: expect function name. (syntax)"}, lintStrings("fn (", cfg))
+}
+
+func TestParseSeverities(t *testing.T) {
+	tests := []struct {
+		settings string
+		want     map[string]Severity
+		err      string
+	}{
+		{settings: "", want: map[string]Severity{}},
+		{
+			settings: "unused-variable=off, shadowed-name=error,wrong-arity=info",
+			want: map[string]Severity{
+				RuleUnusedVariable: SeverityOff,
+				RuleShadowedName:   SeverityError,
+				RuleWrongArity:     SeverityInfo,
+			},
+		},
+		{settings: "unused-variable", err: "invalid severity setting: unused-variable, want rule=severity"},
+		{settings: "syntax=off", err: "unknown lint rule: syntax"},
+		{settings: "unused=off", err: "unknown lint rule: unused"},
+		{settings: "unused-variable=fatal", err: "unknown severity: fatal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.settings, func(t *testing.T) {
+			got, err := ParseSeverities(tt.settings)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiagnosticJSON(t *testing.T) {
+	diagnostics := Source("test.si", []byte("fn f() { return this; }"), nil)
+
+	data, err := json.Marshal(diagnostics)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{
+		"file": "test.si",
+		"line": 1,
+		"column": 17,
+		"rule": "this-outside-method",
+		"severity": "error",
+		"message": "this can only be used in the methods of classes"
+	}]`, string(data))
+}
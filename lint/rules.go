@@ -0,0 +1,546 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/eval"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+// symbol is a declared name
+type symbol struct {
+	name  string
+	token *tokens.Token
+	// local is a let or const in a function or block, it is reported if it is never read,
+	// the names at the top level can be used by the importers, the parameters and loop variables are not reported
+	local bool
+	used  bool
+	// fn is the function declared by the name, the calls to it are checked if the name is never assigned
+	fn       *ast.Function
+	assigned bool
+}
+
+type scope struct {
+	symbols map[string]*symbol
+	order   []*symbol
+	outer   *scope
+	// block is the scope of a block, loop or match arm, the names created by assignment go to the enclosing scope
+	block bool
+}
+
+// call is a call to a function declared in the script, it is checked after all the assignments are seen
+type call struct {
+	call *ast.Call
+	sym  *symbol
+}
+
+type linter struct {
+	file        string
+	cfg         *Config
+	positions   map[*tokens.Token]tokens.Position
+	diagnostics []Diagnostic
+
+	scope *scope
+	// methods is how many methods are around the current node, this can be used in them
+	methods int
+	calls   []call
+}
+
+func (l *linter) report(node ast.Node, rule, format string, args ...interface{}) {
+	l.reportAt(l.positions[tokenOf(node)], rule, fmt.Sprintf(format, args...))
+}
+
+func (l *linter) reportAt(pos tokens.Position, rule, message string) {
+	severity := l.cfg.severity(rule)
+	if severity == SeverityOff {
+		return
+	}
+
+	l.diagnostics = append(l.diagnostics, Diagnostic{
+		File:     l.file,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     rule,
+		Severity: severity,
+		Message:  message,
+	})
+}
+
+func (l *linter) beginScope() {
+	l.scope = &scope{symbols: map[string]*symbol{}, outer: l.scope}
+}
+
+func (l *linter) beginBlock() {
+	l.beginScope()
+	l.scope.block = true
+}
+
+func (l *linter) endScope() {
+	for _, sym := range l.scope.order {
+		if sym.local && !sym.used && !ignored(sym.name) {
+			l.reportAt(l.positions[sym.token], RuleUnusedVariable, fmt.Sprintf("%s is declared but never used", sym.name))
+		}
+	}
+	l.scope = l.scope.outer
+}
+
+func (l *linter) topLevel() bool {
+	return l.scope.outer == nil
+}
+
+// ignored reports whether the name is exempted from unused-variable and shadowed-name, like _ and _tmp
+func ignored(name string) bool {
+	return strings.HasPrefix(name, "_")
+}
+
+func (l *linter) declare(tk *tokens.Token, local bool) *symbol {
+	name := tk.Literal
+	if sym, ok := l.scope.symbols[name]; ok {
+		// the top level names are declared before the statements, a name declared twice is not a known function
+		if sym.token != tk {
+			sym.assigned = true
+		}
+		return sym
+	}
+
+	if !l.topLevel() && !ignored(name) {
+		if outer := l.scope.outer.lookup(name); outer != nil {
+			l.reportAt(l.positions[tk], RuleShadowedName,
+				fmt.Sprintf("%s shadows the declaration at %s", name, l.positions[outer.token]))
+		}
+	}
+
+	sym := &symbol{name: name, token: tk, local: local}
+	l.scope.symbols[name] = sym
+	l.scope.order = append(l.scope.order, sym)
+	return sym
+}
+
+func (s *scope) lookup(name string) *symbol {
+	for ; s != nil; s = s.outer {
+		if sym, ok := s.symbols[name]; ok {
+			return sym
+		}
+	}
+	return nil
+}
+
+func (l *linter) program(program *ast.Program) {
+	l.beginScope()
+	// the functions can use the top level names declared after them
+	for _, stmt := range program.Stmts {
+		l.predeclare(stmt)
+	}
+	l.stmts(program.Stmts)
+	l.endScope()
+
+	for _, c := range l.calls {
+		if !c.sym.assigned {
+			l.arity(c.call, c.sym.fn)
+		}
+	}
+}
+
+func (l *linter) predeclare(stmt ast.Stmt) {
+	switch v := stmt.(type) {
+	case *ast.LetStmt:
+		l.declare(v.Ident.Token, false)
+	case *ast.Function:
+		l.declare(v.Name, false).fn = v
+	case *ast.ClassStmt:
+		l.declare(v.NameIdent.Token, false)
+	case *ast.ImportStmt:
+		l.importNames(v)
+	case *ast.ExportStmt:
+		l.predeclare(v.Stmt)
+	}
+}
+
+func (l *linter) importNames(im *ast.ImportStmt) {
+	for _, name := range im.Names {
+		l.declare(name, false)
+	}
+	if im.Alias != nil {
+		l.declare(im.Alias, false)
+	}
+}
+
+func (l *linter) stmts(stmts []ast.Stmt) {
+	for idx, stmt := range stmts {
+		l.stmt(stmt)
+		if ast.AlwaysReturns(stmt) && idx+1 < len(stmts) {
+			l.report(stmts[idx+1], RuleUnreachableCode, "unreachable code after return")
+			l.stmts(stmts[idx+1:])
+			return
+		}
+	}
+}
+
+func (l *linter) stmt(stmt ast.Stmt) {
+	switch v := stmt.(type) {
+	case *ast.LetStmt:
+		l.expr(v.InitExpr)
+		l.declare(v.Ident.Token, !l.topLevel())
+	case *ast.Function:
+		l.declare(v.Name, false).fn = v
+		l.function(v)
+	case *ast.ClassStmt:
+		l.declare(v.NameIdent.Token, false)
+		l.methods++
+		for _, method := range v.MethodList {
+			l.function(method)
+		}
+		l.methods--
+	case *ast.Block:
+		l.beginBlock()
+		l.stmts(v.Statements)
+		l.endScope()
+	case *ast.ExpressionStmt:
+		l.expr(v.Expr)
+	case *ast.IFStmt:
+		l.expr(v.Condition)
+		l.stmt(v.ThenBranch)
+		if v.ElseBranch != nil {
+			l.stmt(v.ElseBranch)
+		}
+	case *ast.WhileStmt:
+		l.expr(v.Condition)
+		l.stmt(v.Body)
+	case *ast.ForStmt:
+		l.beginBlock()
+		if v.Init != nil {
+			l.stmt(v.Init)
+		}
+		l.expr(v.Condition)
+		l.expr(v.Increment)
+		l.stmt(v.Body)
+		l.endScope()
+	case *ast.ForInStmt:
+		l.expr(v.Iterable)
+		l.beginBlock()
+		if v.Key != nil {
+			l.declare(v.Key.Token, false)
+		}
+		l.declare(v.Value.Token, false)
+		l.stmt(v.Body)
+		l.endScope()
+	case *ast.ReturnStmt:
+		l.expr(v.Value)
+	case *ast.YieldStmt:
+		l.expr(v.Value)
+	case *ast.PrintStmt:
+		for _, value := range v.Values {
+			l.expr(value)
+		}
+	case *ast.ImportStmt:
+		l.importNames(v)
+	case *ast.ExportStmt:
+		l.stmt(v.Stmt)
+	}
+}
+
+func (l *linter) function(fn *ast.Function) {
+	l.beginScope()
+	defer l.endScope()
+
+	for _, param := range fn.Parameters {
+		l.declare(param, false)
+	}
+	if fn.Rest != nil {
+		l.declare(fn.Rest, false)
+	}
+	for _, value := range fn.Defaults {
+		l.expr(value)
+	}
+
+	// the functions declared in methods are closures of the method, so this can be used in them too
+	l.stmt(fn.Body)
+}
+
+func (l *linter) expr(expr ast.Expression) {
+	if expr == nil {
+		return
+	}
+
+	ast.Inspect(expr, func(node ast.Node) bool {
+		switch v := node.(type) {
+		case *ast.Identifier:
+			if sym := l.scope.lookup(v.Name); sym != nil {
+				sym.used = true
+			}
+		case *ast.ThisExpr:
+			if l.methods == 0 {
+				l.report(v, RuleThisOutsideMethod, "this can only be used in the methods of classes")
+			}
+		case *ast.Assign:
+			l.assign(v, v.Name)
+		case *ast.CompoundAssign:
+			if ident, ok := v.Target.(*ast.Identifier); ok {
+				l.update(ident)
+				l.expr(v.Value)
+				return false
+			}
+		case *ast.DExp:
+			if ident, ok := v.Left.(*ast.Identifier); ok {
+				l.update(ident)
+				return false
+			}
+		case *ast.Call:
+			l.call(v)
+		case *ast.Match:
+			l.match(v)
+			return false
+		}
+		return true
+	})
+}
+
+// assign checks `name = value`, which declares the name in the enclosing function or the top level if it is not declared
+func (l *linter) assign(node ast.Node, name *tokens.Token) {
+	sym := l.scope.lookup(name.Literal)
+	if sym == nil {
+		l.report(node, RuleUndeclaredAssign, "%s is assigned before it is declared, the assignment declares it in the enclosing function or the top level", name.Literal)
+		current := l.scope
+		for l.scope.block {
+			l.scope = l.scope.outer
+		}
+		l.declare(name, false).assigned = true
+		l.scope = current
+		return
+	}
+	sym.assigned = true
+}
+
+// update checks +=, ++ and the others, which read the variable before they assign it
+func (l *linter) update(ident *ast.Identifier) {
+	sym := l.scope.lookup(ident.Name)
+	if sym == nil {
+		l.report(ident, RuleUndeclaredAssign, "%s is updated but it is not declared", ident.Name)
+		return
+	}
+	sym.assigned = true
+}
+
+func (l *linter) call(c *ast.Call) {
+	callee := c.Callee
+	for {
+		group, ok := callee.(*ast.Grouping)
+		if !ok {
+			break
+		}
+		callee = group.Expr
+	}
+
+	switch v := callee.(type) {
+	case *ast.Literal, *ast.Slice, *ast.MapLiteral:
+		l.report(c, RuleNotCallable, "%s is not callable", ast.Format(v))
+	case *ast.Identifier:
+		if sym := l.scope.lookup(v.Name); sym != nil && sym.fn != nil {
+			l.calls = append(l.calls, call{call: c, sym: sym})
+		}
+	}
+}
+
+func (l *linter) match(m *ast.Match) {
+	l.expr(m.Subject)
+	for _, arm := range m.Arms {
+		l.beginBlock()
+		for _, pattern := range arm.Patterns {
+			if pattern.Kind == ast.PatternBinding && !pattern.IsWildcard() {
+				l.declare(pattern.Name, false)
+			}
+		}
+		l.expr(arm.Guard)
+		l.stmt(arm.Body)
+		l.endScope()
+	}
+}
+
+// arity checks the arguments of the call bind the parameters of fn like the evaluator does
+func (l *linter) arity(c *ast.Call, fn *ast.Function) {
+	name := fn.Name.Literal
+	params := fn.Parameters
+
+	positional := 0
+	var keywords []*ast.KeywordArg
+	for _, arg := range c.Arguments {
+		switch v := arg.(type) {
+		case *ast.Spread:
+			// the number of the arguments is only known when it runs
+			return
+		case *ast.KeywordArg:
+			keywords = append(keywords, v)
+		default:
+			positional++
+		}
+	}
+
+	required := 0
+	for idx := range params {
+		if fn.Defaults == nil || fn.Defaults[idx] == nil {
+			required++
+		}
+	}
+	max := len(params)
+	if fn.Rest != nil {
+		max = -1
+	}
+	wrongNumber := func() {
+		l.report(c, RuleWrongArity, eval.ErrWrongNumberOfArguments, name, positional, wantArgs(required, max))
+	}
+
+	if positional > len(params) && fn.Rest == nil {
+		wrongNumber()
+		return
+	}
+
+	bound := make([]bool, len(params))
+	for idx := 0; idx < positional && idx < len(params); idx++ {
+		bound[idx] = true
+	}
+	for _, kw := range keywords {
+		idx := -1
+		for i, param := range params {
+			if param.Literal == kw.Name.Literal {
+				idx = i
+			}
+		}
+
+		if idx < 0 {
+			l.report(kw, RuleWrongArity, eval.ErrUnknownKeywordArg, name, kw.Name.Literal)
+			return
+		}
+		if bound[idx] {
+			l.report(kw, RuleWrongArity, eval.ErrDuplicateArgument, name, kw.Name.Literal)
+			return
+		}
+		bound[idx] = true
+	}
+
+	var missing []string
+	for idx, param := range params {
+		if !bound[idx] && (fn.Defaults == nil || fn.Defaults[idx] == nil) {
+			missing = append(missing, param.Literal)
+		}
+	}
+	if len(missing) > 0 {
+		if len(keywords) == 0 {
+			wrongNumber()
+			return
+		}
+		l.report(c, RuleWrongArity, eval.ErrMissingArguments, name, strings.Join(missing, ", "))
+	}
+}
+
+// wantArgs describes the number of arguments between min and max like the evaluator, max < 0 means no limit
+func wantArgs(min, max int) string {
+	switch {
+	case max < 0:
+		return fmt.Sprintf("at least %d", min)
+	case max != min:
+		return fmt.Sprintf("%d to %d", min, max)
+	}
+	return fmt.Sprintf("%d", min)
+}
+
+// tokenOf returns the first token of node which is kept in the tree, nil if there is none,
+// the statements like if and print do not keep their keywords, so they are found by their first expression
+func tokenOf(node ast.Node) *tokens.Token {
+	switch v := node.(type) {
+	case *ast.Identifier:
+		return v.Token
+	case *ast.Literal:
+		return v.Value
+	case *ast.ThisExpr:
+		return v.Keyword()
+	case *ast.Assign:
+		return v.Name
+	case *ast.KeywordArg:
+		return v.Name
+	case *ast.Unary:
+		return v.Operator
+	case *ast.DExp:
+		if v.Prefix {
+			return v.Operator
+		}
+		return tokenOf(v.Left)
+	case *ast.CompoundAssign:
+		return tokenOf(v.Target)
+	case *ast.Binary:
+		return tokenOf(v.Left)
+	case *ast.Logical:
+		return tokenOf(v.Left)
+	case *ast.Call:
+		return tokenOf(v.Callee)
+	case *ast.Get:
+		return tokenOf(v.Expr)
+	case *ast.Set:
+		return tokenOf(v.Expr)
+	case *ast.OptionalChain:
+		return tokenOf(v.Expr)
+	case *ast.Grouping:
+		return tokenOf(v.Expr)
+	case *ast.Spread:
+		return tokenOf(v.Expr)
+	case *ast.Ternary:
+		return tokenOf(v.Condition)
+	case *ast.SliceAccess:
+		return tokenOf(v.Name)
+	case *ast.SliceRange:
+		return tokenOf(v.Name)
+	case *ast.SliceElementAssign:
+		return tokenOf(v.SLA)
+	case *ast.Match:
+		return tokenOf(v.Subject)
+	case *ast.Slice:
+		if len(v.Elements) > 0 {
+			return tokenOf(v.Elements[0])
+		}
+	case *ast.MapLiteral:
+		if len(v.Pairs) > 0 {
+			return tokenOf(v.Pairs[0].Key)
+		}
+	case *ast.LetStmt:
+		return v.Ident.Token
+	case *ast.Function:
+		return v.Name
+	case *ast.ClassStmt:
+		return v.NameIdent.Token
+	case *ast.ReturnStmt:
+		return v.Keyword
+	case *ast.YieldStmt:
+		return v.Keyword
+	case *ast.ExpressionStmt:
+		return tokenOf(v.Expr)
+	case *ast.PrintStmt:
+		if len(v.Values) > 0 {
+			return tokenOf(v.Values[0])
+		}
+	case *ast.IFStmt:
+		return tokenOf(v.Condition)
+	case *ast.WhileStmt:
+		return tokenOf(v.Condition)
+	case *ast.ForStmt:
+		for _, n := range []ast.Node{v.Init, v.Condition, v.Increment, v.Body} {
+			if n != nil && tokenOf(n) != nil {
+				return tokenOf(n)
+			}
+		}
+	case *ast.ForInStmt:
+		if v.Key != nil {
+			return v.Key.Token
+		}
+		return v.Value.Token
+	case *ast.Block:
+		if len(v.Statements) > 0 {
+			return tokenOf(v.Statements[0])
+		}
+	case *ast.ImportStmt:
+		return v.Path
+	case *ast.ExportStmt:
+		return tokenOf(v.Stmt)
+	}
+	return nil
+}
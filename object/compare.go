@@ -39,3 +39,22 @@ func (obj *String) Compare(op tokens.TokenType, other *String) bool {
 
 	return false
 }
+
+func (obj *Float) Compare(op tokens.TokenType, other *Float) bool {
+	switch op {
+	case tokens.GT:
+		return obj.Value > other.Value
+	case tokens.GTEQ:
+		return obj.Value >= other.Value
+	case tokens.LT:
+		return obj.Value < other.Value
+	case tokens.LTEQ:
+		return obj.Value <= other.Value
+	case tokens.NOTEQUAL:
+		return obj.Value != other.Value
+	case tokens.EQUAL:
+		return obj.Value == other.Value
+	}
+
+	return false
+}
@@ -1,8 +1,18 @@
 package object
 
+import "fmt"
+
+var (
+	ErrRedeclared    = "identifier: %s is already declared"
+	ErrAssignToConst = "can not assign to constant: %s"
+)
+
 type Environment struct {
-	kv     map[string]Object
-	outter *Environment // the outter env for a function
+	kv       map[string]Object
+	consts   map[string]struct{} // the names declared by const
+	outter   *Environment        // the outter env for a function
+	settings any                 // interpreter settings, only the global env has it
+	block    bool                // the scope of a block, the names created by assignment go to the enclosing scope
 }
 
 func NewEnvWithOutter(outter *Environment) *Environment {
@@ -12,6 +22,13 @@ func NewEnvWithOutter(outter *Environment) *Environment {
 	}
 }
 
+// NewBlockEnv creates the scope of a block in outter, like the body of if, a loop iteration or a match arm
+func NewBlockEnv(outter *Environment) *Environment {
+	env := NewEnvWithOutter(outter)
+	env.block = true
+	return env
+}
+
 func NewEnvironment() *Environment {
 	return &Environment{
 		kv:     make(map[string]Object, 10),
@@ -19,6 +36,23 @@ func NewEnvironment() *Environment {
 	}
 }
 
+// NewEnvironmentWithSettings creates a global env which carries the interpreter settings
+func NewEnvironmentWithSettings(settings any) *Environment {
+	env := NewEnvironment()
+	env.settings = settings
+	return env
+}
+
+// Settings returns the settings of the closest env which has them
+func (env *Environment) Settings() any {
+	for e := env; e != nil; e = e.outter {
+		if e.settings != nil {
+			return e.settings
+		}
+	}
+	return nil
+}
+
 func (env *Environment) Get(identifier string) (Object, bool) {
 	obj, ok := env.kv[identifier]
 	if !ok && env.outter != nil {
@@ -31,3 +65,42 @@ func (env *Environment) Set(identifier string, value Object) Object {
 	env.kv[identifier] = value
 	return value
 }
+
+// Define declares a variable in env, the name can not be declared twice in the same env
+func (env *Environment) Define(identifier string, value Object, isConst bool) error {
+	if _, ok := env.kv[identifier]; ok {
+		return fmt.Errorf(ErrRedeclared, identifier)
+	}
+
+	env.kv[identifier] = value
+	if isConst {
+		if env.consts == nil {
+			env.consts = make(map[string]struct{})
+		}
+		env.consts[identifier] = struct{}{}
+	}
+	return nil
+}
+
+// Assign updates the closest variable named identifier, if it is not found,
+// the variable is declared in the closest env which is not a block, the env of the function or the global env
+func (env *Environment) Assign(identifier string, value Object) error {
+	for e := env; e != nil; e = e.outter {
+		if _, ok := e.kv[identifier]; !ok {
+			continue
+		}
+
+		if _, ok := e.consts[identifier]; ok {
+			return fmt.Errorf(ErrAssignToConst, identifier)
+		}
+		e.kv[identifier] = value
+		return nil
+	}
+
+	e := env
+	for e.block && e.outter != nil {
+		e = e.outter
+	}
+	e.kv[identifier] = value
+	return nil
+}
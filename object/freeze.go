@@ -0,0 +1,35 @@
+package object
+
+// Freeze makes obj and all the slices, maps and class instances reachable from it read-only
+func Freeze(obj Object) {
+	switch v := obj.(type) {
+	case *Slice:
+		if v.Frozen {
+			return
+		}
+
+		v.Frozen = true
+		for _, e := range v.Elements {
+			Freeze(e)
+		}
+	case *Map:
+		if v.Frozen {
+			return
+		}
+
+		v.Frozen = true
+		for _, pair := range v.Pairs() {
+			Freeze(pair.Key)
+			Freeze(pair.Value)
+		}
+	case *ClassInstance:
+		if v.Frozen {
+			return
+		}
+
+		v.Frozen = true
+		for _, field := range v.Fields {
+			Freeze(field)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package object
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+// Repr returns the representation of obj which reads back as the same value:
+// strings are quoted, slices are [1, "a", true], maps are {"k": 1}, and instances are Point{x: 1, y: 2}.
+// a slice, map or instance which contains itself is shown as [...], {...} or Point{...}.
+func Repr(obj Object) string {
+	p := &printer{visiting: map[Object]bool{}}
+	p.repr(obj)
+	return p.sb.String()
+}
+
+// Str returns the string to print for obj, it is the same as Repr except strings are not quoted
+func Str(obj Object) string {
+	if s, ok := obj.(*String); ok {
+		return s.Value
+	}
+	return Repr(obj)
+}
+
+// printer writes the representation of objects, it remembers the containers being printed to stop at the cycles
+type printer struct {
+	sb       strings.Builder
+	visiting map[Object]bool
+}
+
+func (p *printer) repr(obj Object) {
+	switch v := obj.(type) {
+	case nil:
+		p.sb.WriteString("null")
+	case *String:
+		p.sb.WriteString(tokens.Quote(v.Value))
+	case *Slice:
+		p.slice(v)
+	case *Map:
+		p.mapObj(v)
+	case *ClassInstance:
+		p.instance(v)
+	default:
+		p.sb.WriteString(obj.Inspect())
+	}
+}
+
+// enter marks obj as being printed, it returns false if obj is already being printed
+func (p *printer) enter(obj Object) bool {
+	if p.visiting[obj] {
+		return false
+	}
+	p.visiting[obj] = true
+	return true
+}
+
+func (p *printer) leave(obj Object) {
+	delete(p.visiting, obj)
+}
+
+func (p *printer) slice(sl *Slice) {
+	if !p.enter(sl) {
+		p.sb.WriteString("[...]")
+		return
+	}
+	defer p.leave(sl)
+
+	p.sb.WriteByte('[')
+	for idx, e := range sl.Elements {
+		if idx > 0 {
+			p.sb.WriteString(", ")
+		}
+		p.repr(e)
+	}
+	p.sb.WriteByte(']')
+}
+
+func (p *printer) mapObj(m *Map) {
+	if !p.enter(m) {
+		p.sb.WriteString("{...}")
+		return
+	}
+	defer p.leave(m)
+
+	p.sb.WriteByte('{')
+	for idx, pair := range m.Pairs() {
+		if idx > 0 {
+			p.sb.WriteString(", ")
+		}
+		p.repr(pair.Key)
+		p.sb.WriteString(": ")
+		p.repr(pair.Value)
+	}
+	p.sb.WriteByte('}')
+}
+
+// instance writes the fields sorted by name
+func (p *printer) instance(inst *ClassInstance) {
+	p.sb.WriteString(inst.Cls.Name)
+	if !p.enter(inst) {
+		p.sb.WriteString("{...}")
+		return
+	}
+	defer p.leave(inst)
+
+	names := make([]string, 0, len(inst.Fields))
+	for name := range inst.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	p.sb.WriteByte('{')
+	for idx, name := range names {
+		if idx > 0 {
+			p.sb.WriteString(", ")
+		}
+		p.sb.WriteString(name)
+		p.sb.WriteString(": ")
+		p.repr(inst.Fields[name])
+	}
+	p.sb.WriteByte('}')
+}
@@ -0,0 +1,40 @@
+package object
+
+// Iterator produces the elements of a collection one by one,
+// scripts call it.next() to get the next element: {"done": false, "value": v}
+type Iterator struct {
+	// Next returns the key and value of the next element, done is true when there are no more elements.
+	// the key is the index of slice and string, the key of map, or the count of elements before it
+	Next func() (key, value Object, done bool, err error)
+	// Close stops the iterator before it is drained, nil if the iterator holds nothing to release
+	Close func()
+	// Name is printed by Inspect, it is "iterator" if empty
+	Name string
+}
+
+func (it *Iterator) Inspect() string {
+	if it.Name != "" {
+		return it.Name
+	}
+	return "iterator"
+}
+
+func (it *Iterator) Type() ObjectType {
+	return OBJ_ITERATOR
+}
+
+// NewSliceIterator returns the iterator over elements, the keys are the indexes
+func NewSliceIterator(elements []Object) *Iterator {
+	idx := 0
+	return &Iterator{
+		Next: func() (Object, Object, bool, error) {
+			if idx >= len(elements) {
+				return nil, nil, true, nil
+			}
+
+			key, value := &Integer{Value: int64(idx)}, elements[idx]
+			idx++
+			return key, value, false, nil
+		},
+	}
+}
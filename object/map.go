@@ -0,0 +1,136 @@
+package object
+
+import (
+	"fmt"
+	"strconv"
+)
+
+var (
+	ErrUnhashableKey = "unhashable map key: %s"
+)
+
+// HashKey identifies a map key by its type and value
+type HashKey struct {
+	Type  ObjectType
+	Value string
+}
+
+// Hashable is implemented by the objects which can be used as map key
+type Hashable interface {
+	HashKey() HashKey
+}
+
+func (i *Integer) HashKey() HashKey {
+	return HashKey{Type: OBJ_INTEGER, Value: strconv.FormatInt(i.Value, 10)}
+}
+
+func (s *String) HashKey() HashKey {
+	return HashKey{Type: OBJ_STRING, Value: s.Value}
+}
+
+func (b *Bool) HashKey() HashKey {
+	return HashKey{Type: OBJ_BOOL, Value: strconv.FormatBool(b.Value)}
+}
+
+type MapPair struct {
+	Key   Object
+	Value Object
+}
+
+// Map is a hash map which keeps the insertion order of its keys
+type Map struct {
+	pairs  map[HashKey]*MapPair
+	order  []HashKey
+	Frozen bool // the pairs can not be changed after freeze(map)
+}
+
+func NewMap() *Map {
+	return &Map{pairs: make(map[HashKey]*MapPair)}
+}
+
+func hashKeyOf(key Object) (HashKey, error) {
+	h, ok := key.(Hashable)
+	if !ok {
+		return HashKey{}, fmt.Errorf(ErrUnhashableKey, key.Inspect())
+	}
+	return h.HashKey(), nil
+}
+
+func (m *Map) Get(key Object) (Object, bool, error) {
+	hk, err := hashKeyOf(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	pair, ok := m.pairs[hk]
+	if !ok {
+		return nil, false, nil
+	}
+	return pair.Value, true, nil
+}
+
+func (m *Map) Set(key Object, value Object) error {
+	if m.Frozen {
+		return fmt.Errorf(ErrFrozen, "map", m.Inspect())
+	}
+
+	hk, err := hashKeyOf(key)
+	if err != nil {
+		return err
+	}
+
+	if pair, ok := m.pairs[hk]; ok {
+		pair.Value = value
+		return nil
+	}
+
+	m.pairs[hk] = &MapPair{Key: key, Value: value}
+	m.order = append(m.order, hk)
+	return nil
+}
+
+// Delete removes the key from map, and reports whether the key exists
+func (m *Map) Delete(key Object) (bool, error) {
+	if m.Frozen {
+		return false, fmt.Errorf(ErrFrozen, "map", m.Inspect())
+	}
+
+	hk, err := hashKeyOf(key)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := m.pairs[hk]; !ok {
+		return false, nil
+	}
+
+	delete(m.pairs, hk)
+	for idx, k := range m.order {
+		if k == hk {
+			m.order = append(m.order[:idx], m.order[idx+1:]...)
+			break
+		}
+	}
+	return true, nil
+}
+
+func (m *Map) Len() int {
+	return len(m.order)
+}
+
+// Pairs returns the key value pairs in insertion order
+func (m *Map) Pairs() []*MapPair {
+	pairs := make([]*MapPair, 0, len(m.order))
+	for _, k := range m.order {
+		pairs = append(pairs, m.pairs[k])
+	}
+	return pairs
+}
+
+func (m *Map) Inspect() string {
+	return Repr(m)
+}
+
+func (m *Map) Type() ObjectType {
+	return OBJ_MAP
+}
@@ -2,6 +2,7 @@ package object
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 
@@ -10,8 +11,10 @@ import (
 )
 
 var (
-	ErrPropertyNotFound = "property: %s not found for instance: %s"
-	ErrIdxOutofBound    = "idx: %d is out of bound"
+	ErrPropertyNotFound     = "property: %s not found for instance: %s"
+	ErrIdxOutofBound        = "idx: %d is out of bound"
+	ErrModuleMemberNotFound = "module %s has no member: %s"
+	ErrFrozen               = "can not modify frozen %s: %s"
 )
 
 type ObjectType string
@@ -51,6 +54,7 @@ func (cls *Class) findMethod(name string) (*Function, bool) {
 type ClassInstance struct {
 	Cls    *Class
 	Fields map[string]Object
+	Frozen bool // the fields can not be changed after freeze(instance)
 }
 
 func NewClassInstance(cls *Class) *ClassInstance {
@@ -76,12 +80,17 @@ func (instance *ClassInstance) Get(name *tokens.Token) (Object, error) {
 	return nil, fmt.Errorf(ErrPropertyNotFound, name.Literal, instance.Inspect())
 }
 
-func (instance *ClassInstance) Set(name *tokens.Token, value Object) {
+func (instance *ClassInstance) Set(name *tokens.Token, value Object) error {
+	if instance.Frozen {
+		return fmt.Errorf(ErrFrozen, "instance", instance.Inspect())
+	}
+
 	instance.Fields[name.Literal] = value
+	return nil
 }
 
 func (instance *ClassInstance) Inspect() string {
-	return instance.Cls.Name + ":instance"
+	return Repr(instance)
 }
 
 func (instance *ClassInstance) Type() ObjectType {
@@ -89,30 +98,44 @@ func (instance *ClassInstance) Type() ObjectType {
 }
 
 type Function struct {
+	Name       string // empty for the anonymous functions
 	Parameters []*ast.Identifier
+	Defaults   []ast.Expression // the default values of Parameters, nil if no parameter has one
+	Rest       *ast.Identifier  // the rest parameter, which receives the extra arguments as a slice
 	Body       *ast.Block
 	Env        *Environment
+	Generator  bool // calling the function returns a generator
 }
 
 func (fn *Function) bind(instance *ClassInstance) *Function {
 	newEnv := NewEnvWithOutter(fn.Env)
 	newEnv.Set("this", instance)
-	return &Function{
-		Parameters: fn.Parameters,
-		Body:       fn.Body,
-		Env:        newEnv,
-	}
+
+	bound := *fn
+	bound.Env = newEnv
+	return &bound
 }
 
 func (fn *Function) Inspect() string {
 	sb := &strings.Builder{}
-	sb.WriteString("fn(")
+	sb.WriteString("fn")
+	if fn.Name != "" {
+		sb.WriteString(" " + fn.Name)
+	}
+	sb.WriteString("(")
 
 	var ps []string
-	for _, p := range fn.Parameters {
-		ps = append(ps, p.Name)
+	for idx, p := range fn.Parameters {
+		if fn.Defaults == nil || fn.Defaults[idx] == nil {
+			ps = append(ps, p.Name)
+			continue
+		}
+		ps = append(ps, p.Name+" = "+ast.Format(fn.Defaults[idx]))
 	}
-	sb.WriteString(strings.Join(ps, ","))
+	if fn.Rest != nil {
+		ps = append(ps, "..."+fn.Rest.Name)
+	}
+	sb.WriteString(strings.Join(ps, ", "))
 	sb.WriteString(")")
 	return sb.String()
 }
@@ -121,6 +144,23 @@ func (fn *Function) Type() ObjectType {
 	return OBJ_FUNCTION
 }
 
+// BuiltinFunction is the go implementation of a builtin function,
+// it receives the already evaluated arguments.
+type BuiltinFunction func(args ...Object) (Object, error)
+
+type Builtin struct {
+	Name string
+	Fn   BuiltinFunction
+}
+
+func (b *Builtin) Inspect() string {
+	return "builtin fn " + b.Name
+}
+
+func (b *Builtin) Type() ObjectType {
+	return OBJ_BUILTIN
+}
+
 type Integer struct {
 	Value int64
 }
@@ -132,21 +172,58 @@ func (i *Integer) Type() ObjectType {
 	return OBJ_INTEGER
 }
 
+// BigInt is an arbitrary-precision integer,
+// integers are promoted to it when int64 overflows and the interpreter allows it
+type BigInt struct {
+	Value *big.Int
+}
+
+func (b *BigInt) Inspect() string {
+	return b.Value.String()
+}
+func (b *BigInt) Type() ObjectType {
+	return OBJ_BIGINT
+}
+
+type Float struct {
+	Value float64
+}
+
+func (f *Float) Inspect() string {
+	s := strconv.FormatFloat(f.Value, 'f', -1, 64)
+	if !strings.ContainsAny(s, ".IN") {
+		s += ".0"
+	}
+	return s
+}
+func (f *Float) Type() ObjectType {
+	return OBJ_FLOAT
+}
+
 type Slice struct {
 	Elements []Object
+	Frozen   bool // the elements can not be changed after freeze(slice)
 }
 
 func (sl *Slice) Inspect() string {
-	return fmt.Sprintf("slice: [%d]", len(sl.Elements))
+	return Repr(sl)
 }
 func (sl *Slice) Type() ObjectType {
 	return OBJ_SLICE
 }
-func (sl *Slice) Append(e ...Object) {
+func (sl *Slice) Append(e ...Object) error {
+	if sl.Frozen {
+		return fmt.Errorf(ErrFrozen, "slice", sl.Inspect())
+	}
+
 	sl.Elements = append(sl.Elements, e...)
+	return nil
 }
 
 func (sl *Slice) Set(obj Object, idx int) error {
+	if sl.Frozen {
+		return fmt.Errorf(ErrFrozen, "slice", sl.Inspect())
+	}
 	if idx < 0 || idx >= len(sl.Elements) {
 		return fmt.Errorf(ErrIdxOutofBound, idx)
 	}
@@ -154,6 +231,60 @@ func (sl *Slice) Set(obj Object, idx int) error {
 	return nil
 }
 
+// Insert put obj at idx, and shift the elements after idx to the right
+func (sl *Slice) Insert(obj Object, idx int) error {
+	if sl.Frozen {
+		return fmt.Errorf(ErrFrozen, "slice", sl.Inspect())
+	}
+	if idx < 0 || idx > len(sl.Elements) {
+		return fmt.Errorf(ErrIdxOutofBound, idx)
+	}
+
+	sl.Elements = append(sl.Elements, nil)
+	copy(sl.Elements[idx+1:], sl.Elements[idx:])
+	sl.Elements[idx] = obj
+	return nil
+}
+
+// Remove delete the element at idx and return it
+func (sl *Slice) Remove(idx int) (Object, error) {
+	if sl.Frozen {
+		return nil, fmt.Errorf(ErrFrozen, "slice", sl.Inspect())
+	}
+	if idx < 0 || idx >= len(sl.Elements) {
+		return nil, fmt.Errorf(ErrIdxOutofBound, idx)
+	}
+
+	obj := sl.Elements[idx]
+	sl.Elements = append(sl.Elements[:idx], sl.Elements[idx+1:]...)
+	return obj, nil
+}
+
+// Module is a namespace of named objects, like the builtin math module
+type Module struct {
+	Name    string
+	Members map[string]Object
+}
+
+func NewModule(name string, members map[string]Object) *Module {
+	return &Module{Name: name, Members: members}
+}
+
+func (m *Module) Get(name *tokens.Token) (Object, error) {
+	v, ok := m.Members[name.Literal]
+	if !ok {
+		return nil, fmt.Errorf(ErrModuleMemberNotFound, m.Name, name.Literal)
+	}
+	return v, nil
+}
+
+func (m *Module) Inspect() string {
+	return "module " + m.Name
+}
+func (m *Module) Type() ObjectType {
+	return OBJ_MODULE
+}
+
 type Bool struct {
 	Value bool
 }
@@ -203,6 +334,16 @@ func (ret *Return) Type() ObjectType {
 	return OBJ_RETURN
 }
 
+// Break is the result of break, it stops the innermost loop
+type Break struct{}
+
+func (b *Break) Inspect() string {
+	return "break"
+}
+func (b *Break) Type() ObjectType {
+	return OBJ_BREAK
+}
+
 type Print struct {
 }
 
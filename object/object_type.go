@@ -4,12 +4,19 @@ const (
 	OBJ_CLASS          ObjectType = "CLASS"
 	OBJ_CLASS_INSTANCE ObjectType = "CLASS_INSTANCE"
 	OBJ_FUNCTION       ObjectType = "FUNCTION"
+	OBJ_BUILTIN        ObjectType = "BUILTIN"
 	OBJ_INTEGER        ObjectType = "INTEGER"
+	OBJ_FLOAT          ObjectType = "FLOAT"
+	OBJ_BIGINT         ObjectType = "BIGINT"
 	OBJ_BOOL           ObjectType = "BOOL"
 	OBJ_STRING         ObjectType = "STRING"
 	OBJ_NULL           ObjectType = "NULL"
 	OBJ_RETURN         ObjectType = "RETURN"
+	OBJ_BREAK          ObjectType = "BREAK"
 	OBJ_PRINT          ObjectType = "PRINT"
 	OBJ_SLICE          ObjectType = "SLICE"
+	OBJ_MAP            ObjectType = "MAP"
+	OBJ_MODULE         ObjectType = "MODULE"
+	OBJ_ITERATOR       ObjectType = "ITERATOR"
 	OBJ_ERROR          ObjectType = "ERROR"
 )
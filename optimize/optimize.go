@@ -0,0 +1,246 @@
+// Package optimize rewrites programs into equivalent ones which do less work when they run
+package optimize
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/eval"
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/tokens"
+)
+
+/*
+Program optimizes program in place for the interpreter configured by opts, and returns it:
+  - the expressions of literals are folded: arithmetic, string concatenation, comparisons, !, -, &&, ||, ?? and ?:,
+    and && || ?? ?: whose left operand or condition is a literal are reduced to the operand which is evaluated
+  - the branches of if, while and for which never run are pruned, and so are the statements after return and break
+  - the groupings which do not change the precedence are inlined, like (1) and (f(x))
+
+The expressions are folded by the evaluator, with the same options as the program,
+and an expression is kept if evaluating it fails, so the runtime errors like `1 / 0` still happen when it runs.
+The program is left unchanged if Resolve reports errors, they are reported when it runs.
+*/
+func Program(program *ast.Program, opts *eval.Options) *ast.Program {
+	if err := eval.Resolve(program); err != nil {
+		return program
+	}
+
+	o := &optimizer{env: eval.NewEnvironment(opts), strictBool: opts != nil && opts.StrictBool}
+	ast.Apply(program, nil, o.post)
+	return program
+}
+
+type optimizer struct {
+	// env is an empty environment to evaluate the constant expressions
+	env        *object.Environment
+	strictBool bool
+}
+
+// post is called after the children of the node are optimized, so the constant operands are literals already
+func (o *optimizer) post(c *ast.Cursor) bool {
+	switch n := c.Node().(type) {
+	case *ast.Grouping:
+		if inlinable(n.Expr) {
+			c.Replace(n.Expr)
+		}
+	case *ast.Unary:
+		if isLiteral(n.Right) {
+			o.fold(c, n)
+		}
+	case *ast.Binary:
+		if isLiteral(n.Left) && isLiteral(n.Right) {
+			o.fold(c, n)
+		}
+	case *ast.Logical:
+		o.logical(c, n)
+	case *ast.Ternary:
+		if truth, ok := o.condition(n.Condition); ok {
+			if truth {
+				c.Replace(n.Then)
+			} else {
+				c.Replace(n.Else)
+			}
+		}
+	case *ast.IFStmt:
+		if isEmptyBlock(n.ElseBranch) {
+			n.ElseBranch = nil
+		}
+		if truth, ok := o.condition(n.Condition); ok {
+			if truth {
+				c.Replace(n.ThenBranch)
+			} else if n.ElseBranch != nil {
+				c.Replace(n.ElseBranch)
+			} else {
+				c.Replace(emptyBlock())
+			}
+		}
+	case *ast.WhileStmt:
+		if truth, ok := o.condition(n.Condition); ok && !truth {
+			c.Replace(emptyBlock())
+		}
+	case *ast.ForStmt:
+		if n.Condition == nil {
+			break
+		}
+		if truth, ok := o.condition(n.Condition); ok && !truth {
+			if n.Init == nil {
+				c.Replace(emptyBlock())
+			} else {
+				// the loop gives null like the empty block, not the value of init
+				c.Replace(ast.NewBlockStmt([]ast.Stmt{n.Init, emptyBlock()}))
+			}
+		}
+	case *ast.Block:
+		n.Statements = pruneStmts(n.Statements)
+	case *ast.Program:
+		n.Stmts = pruneStmts(n.Stmts)
+	}
+	return true
+}
+
+// fold replaces the expression with its value if evaluating it succeeds and the value can be a literal
+func (o *optimizer) fold(c *ast.Cursor, expr ast.Expression) {
+	value, err := eval.Eval(expr, o.env)
+	if err != nil {
+		return
+	}
+	if literal := literalOf(value); literal != nil {
+		c.Replace(literal)
+	}
+}
+
+// condition returns the truthiness of expr if it is a literal which can be a condition
+func (o *optimizer) condition(expr ast.Expression) (bool, bool) {
+	if !isLiteral(expr) {
+		return false, false
+	}
+
+	// the condition of ?: follows the truthiness rules of the options, and fails like it in strict mode
+	ternary := ast.NewTernary(expr, literalOf(&object.Bool{Value: true}), literalOf(&object.Bool{Value: false}))
+	value, err := eval.Eval(ternary, o.env)
+	if err != nil {
+		return false, false
+	}
+	truth, ok := value.(*object.Bool)
+	if !ok {
+		return false, false
+	}
+	return truth.Value, true
+}
+
+func (o *optimizer) logical(c *ast.Cursor, lg *ast.Logical) {
+	if !isLiteral(lg.Left) {
+		return
+	}
+	if isLiteral(lg.Right) {
+		o.fold(c, lg)
+		return
+	}
+
+	if lg.Operator.TkType == tokens.DQUESTION {
+		if lg.Left.(*ast.Literal).Value.TkType == tokens.NULL {
+			c.Replace(lg.Right)
+		} else {
+			c.Replace(lg.Left)
+		}
+		return
+	}
+
+	truth, ok := o.condition(lg.Left)
+	if !ok {
+		return
+	}
+	if truth == (lg.Operator.TkType == tokens.OR) {
+		c.Replace(lg.Left)
+	} else if !o.strictBool {
+		// in strict mode the right operand must be a bool value, which is only known when it runs
+		c.Replace(lg.Right)
+	}
+}
+
+// pruneStmts removes the statements after the one which always returns or breaks, and the empty blocks except the last one,
+// the last statement is kept because it gives the value of the statements
+func pruneStmts(stmts []ast.Stmt) []ast.Stmt {
+	pruned := stmts[:0]
+	for idx, stmt := range stmts {
+		if isEmptyBlock(stmt) && idx < len(stmts)-1 {
+			continue
+		}
+
+		pruned = append(pruned, stmt)
+		if returnsOrBreaks(stmt) {
+			break
+		}
+	}
+
+	for idx := len(pruned); idx < len(stmts); idx++ {
+		stmts[idx] = nil
+	}
+	return pruned
+}
+
+// returnsOrBreaks reports whether stmt always returns or breaks the loop, so the statements after it never run,
+// the loops are not looked into, since the break in them only stops themselves
+func returnsOrBreaks(stmt ast.Stmt) bool {
+	switch v := stmt.(type) {
+	case *ast.BreakStmt:
+		return true
+	case *ast.Block:
+		return len(v.Statements) > 0 && returnsOrBreaks(v.Statements[len(v.Statements)-1])
+	case *ast.IFStmt:
+		return v.ElseBranch != nil && returnsOrBreaks(v.ThenBranch) && returnsOrBreaks(v.ElseBranch)
+	}
+	return ast.AlwaysReturns(stmt)
+}
+
+// inlinable reports whether the grouping of expr can be removed without changing how the tree is evaluated
+func inlinable(expr ast.Expression) bool {
+	switch expr.(type) {
+	case *ast.Literal, *ast.Identifier, *ast.ThisExpr, *ast.Grouping, *ast.Call, *ast.Get,
+		*ast.Slice, *ast.MapLiteral, *ast.SliceAccess, *ast.SliceRange:
+		return true
+	}
+	return false
+}
+
+func isLiteral(expr ast.Expression) bool {
+	_, ok := expr.(*ast.Literal)
+	return ok
+}
+
+func isEmptyBlock(stmt ast.Stmt) bool {
+	blk, ok := stmt.(*ast.Block)
+	return ok && len(blk.Statements) == 0
+}
+
+func emptyBlock() *ast.Block {
+	return ast.NewBlockStmt(nil)
+}
+
+// literalOf returns the literal of value, or nil if value can not be written as a literal
+func literalOf(value object.Object) *ast.Literal {
+	switch v := value.(type) {
+	case *object.Integer:
+		return ast.NewLiteral(tokens.NewIntegerToken(v.Value))
+	case *object.Float:
+		if math.IsNaN(v.Value) || math.IsInf(v.Value, 0) {
+			return nil
+		}
+		// 3.0 is written with the dot, or it is lexed as an integer
+		text := strconv.FormatFloat(v.Value, 'f', -1, 64)
+		if !strings.Contains(text, ".") {
+			text += ".0"
+		}
+		return ast.NewLiteral(tokens.NewToken(tokens.FLOAT, text, v.Value))
+	case *object.String:
+		return ast.NewLiteral(tokens.NewStringToken(v.Value))
+	case *object.Bool:
+		return ast.NewLiteral(tokens.NewBoolToken(v.Value))
+	case *object.Null:
+		return ast.NewLiteral(tokens.NewToken(tokens.NULL, tokens.KWNull, nil))
+	}
+	return nil
+}
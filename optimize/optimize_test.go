@@ -0,0 +1,140 @@
+package optimize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forfd8960/simpleinterpreter/ast"
+	"github.com/forfd8960/simpleinterpreter/eval"
+	"github.com/forfd8960/simpleinterpreter/lexer"
+	"github.com/forfd8960/simpleinterpreter/object"
+	"github.com/forfd8960/simpleinterpreter/parser"
+)
+
+func parse(t *testing.T, input string) *ast.Program {
+	tokenList, err := lexer.TokensFromInput(input)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	program, err := parser.NewParser(tokenList).ParseProgram()
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	return program
+}
+
+func TestProgram(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  *eval.Options
+		want  string
+	}{
+		{
+			name:  "arithmetic",
+			input: `let day = 60 * 60 * 24; let x = (1 + 2) * -3 % 5; let f = 1.5 * 2; let p = 2 ** 10; let d = 7 / 2;`,
+			want:  "let day = 86400;\nlet x = -4;\nlet f = 3.0;\nlet p = 1024;\nlet d = 3;\n",
+		},
+		{
+			name:  "strings and comparisons",
+			input: `let s = "a" + "b" + "c"; let b = 1 < 2; let e = "a" == "b"; let n = !(1 > 2); let c = [1] == [1];`,
+			want:  "let s = \"abc\";\nlet b = true;\nlet e = false;\nlet n = true;\nlet c = [1] == [1];\n",
+		},
+		{
+			name:  "only the constant parts are folded",
+			input: `let y = x * (2 + 3); let z = x + 1 + 2; let w = (x);`,
+			want:  "let y = x * 5;\nlet z = x + 1 + 2;\nlet w = x;\n",
+		},
+		{
+			name:  "runtime errors are kept",
+			input: `let a = 1 / 0; let b = 5 % (1 - 1); let c = "a" + 1; let d = -"a"; let e = 9223372036854775807 + 1;`,
+			want:  "let a = 1 / 0;\nlet b = 5 % 0;\nlet c = \"a\" + 1;\nlet d = -\"a\";\nlet e = 9223372036854775807 + 1;\n",
+		},
+		{
+			name:  "logical and conditional",
+			input: `let a = false && f(); let b = true && x; let c = null ?? y; let d = 1 ?? y; let e = 0 || 2; let g = true ? x : y;`,
+			want:  "let a = false;\nlet b = x;\nlet c = y;\nlet d = 1;\nlet e = 2;\nlet g = x;\n",
+		},
+		{
+			name:  "strict bool keeps the conditions which are not bool",
+			input: `let b = true && x; let c = 1 ? x : y; let d = false || x; if (1) { print(1); }`,
+			opts:  &eval.Options{StrictBool: true},
+			want:  "let b = true && x;\nlet c = 1 ? x : y;\nlet d = false || x;\nif (1) {\n\tprint(1);\n}\n",
+		},
+		{
+			name:  "branches",
+			input: `if (false) { print(1); } else { print(2); } if (1 > 2) print(3); if ("") {} else if (true) print(4); while (false) { print(5); } x;`,
+			want:  "{\n\tprint(2);\n}\nprint(4);\nx;\n",
+		},
+		{
+			name:  "for loops",
+			input: `for (let i = 0; 1 > 2; i++) { print(i); } for (; false;) {} for (;;) { print(1); }`,
+			want:  "{\n\tlet i = 0;\n\t{}\n}\nfor (;;) {\n\tprint(1);\n}\n",
+		},
+		{
+			name:  "the last statement gives the value",
+			input: `x; if (false) {}`,
+			want:  "x;\n{}\n",
+		},
+		{
+			name:  "statements after return",
+			input: `fn f(x) { if (x) { return 1; print(1); } else { return 2; } print(3); } fn g() { { return; } print(4); }`,
+			want:  "fn f(x) {\n\tif (x) {\n\t\treturn 1;\n\t} else {\n\t\treturn 2;\n\t}\n}\n\nfn g() {\n\t{\n\t\treturn;\n\t}\n}\n",
+		},
+		{
+			name:  "statements after break",
+			input: `while (x) { if (y) { break; } else { return 1; } print(1); } while (x) { while (y) { break; } print(2); }`,
+			want:  "while (x) {\n\tif (y) {\n\t\tbreak;\n\t} else {\n\t\treturn 1;\n\t}\n}\nwhile (x) {\n\twhile (y) {\n\t\tbreak;\n\t}\n\tprint(2);\n}\n",
+		},
+		{
+			name:  "programs with resolve errors are not changed",
+			input: `const c = 1 + 1; c = 2;`,
+			want:  "const c = 1 + 1;\nc = 2;\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := Program(parse(t, tt.input), tt.opts)
+			assert.Equal(t, tt.want, ast.Format(program))
+		})
+	}
+}
+
+func TestProgramKeepsResults(t *testing.T) {
+	tests := []string{
+		`60 * 60 * 24;`,
+		`let x = 3; x * (2 + 3) - 10 / 2;`,
+		`"a" + "b" == "ab" && !false;`,
+		`fn f(n) { if (1 < 2) { return n * (1 + 1); } return 0; } f(21);`,
+		`fn f() { return 1; print("dead"); } f() + (true ? 1 : 2);`,
+		`let s = 0; for (let i = 0; i < 3 + 2; i++) { s += i * (2 - 1); } s;`,
+		`let xs = [1 + 1, 2 * 2]; xs[0 + 1] + len("ab" + "c");`,
+		`null ?? (1 > 2 || "x");`,
+		`1 / 0;`,
+		`2 ** 70;`,
+		`if (false) {}`,
+	}
+
+	for _, mode := range []eval.OverflowMode{eval.OverflowError, eval.OverflowBigInt} {
+		for _, input := range tests {
+			t.Run(input, func(t *testing.T) {
+				want, err := eval.Eval(parse(t, input), eval.NewEnvironment(&eval.Options{IntOverflow: mode}))
+				assert.Nil(t, err)
+
+				opts := &eval.Options{IntOverflow: mode, Optimizer: Program}
+				got, err := eval.Eval(parse(t, input), eval.NewEnvironment(opts))
+				assert.Nil(t, err)
+				assert.Equal(t, inspect(want), inspect(got))
+			})
+		}
+	}
+}
+
+func inspect(obj object.Object) string {
+	if obj == nil {
+		return "<nil>"
+	}
+	return obj.Inspect()
+}
@@ -11,9 +11,18 @@ var (
 	ErrNotSupportToken = "not supported token: %s"
 )
 
+// maxParameters is the max number of the parameters of a function, including the rest parameter
+const maxParameters = 8
+
 type Parser struct {
 	tokens  []*tokens.Token
 	current int
+	// fnDepth is the number of functions around the current token,
+	// yielded records whether the innermost one contains yield
+	fnDepth int
+	yielded bool
+	// loopDepth is the number of loops around the current token in the innermost function
+	loopDepth int
 }
 
 func NewParser(tokens []*tokens.Token) *Parser {
@@ -27,7 +36,13 @@ func (p *Parser) ParseProgram() (*ast.Program, error) {
 	}
 
 	for !p.isAtEnd() {
-		stmt, err := p.declaration()
+		var stmt ast.Stmt
+		var err error
+		if p.match(tokens.EXPORT) {
+			stmt, err = p.parseExportStmt()
+		} else {
+			stmt, err = p.declaration()
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -40,19 +55,108 @@ func (p *Parser) ParseProgram() (*ast.Program, error) {
 	return program, nil
 }
 
+// Token returns the token the parser is at, it is where the error is found after ParseProgram fails
+func (p *Parser) Token() *tokens.Token {
+	return p.peek()
+}
+
 func (p *Parser) declaration() (ast.Stmt, error) {
 	switch {
 	case p.match(tokens.LET):
 		return p.parseLetStmt()
+	case p.match(tokens.CONST):
+		return p.parseConstStmt()
 	case p.match(tokens.FUNCTION):
 		return p.function()
 	case p.match(tokens.CLASS):
 		return p.parseClassStmt()
+	case p.match(tokens.IMPORT):
+		return p.parseImportStmt()
+	case p.match(tokens.FROM):
+		return p.parseFromImportStmt()
+	case p.check(tokens.EXPORT):
+		return nil, fmt.Errorf("export is only allowed at the top level")
 	default:
 		return p.statement()
 	}
 }
 
+// export let x = 1; export fn f() {} export class C {}
+func (p *Parser) parseExportStmt() (ast.Stmt, error) {
+	var stmt ast.Stmt
+	var err error
+	switch {
+	case p.match(tokens.LET):
+		stmt, err = p.parseLetStmt()
+	case p.match(tokens.CONST):
+		stmt, err = p.parseConstStmt()
+	case p.match(tokens.FUNCTION):
+		stmt, err = p.function()
+	case p.match(tokens.CLASS):
+		stmt, err = p.parseClassStmt()
+	default:
+		return nil, fmt.Errorf("expect let, const, fn or class after export")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewExportStmt(stmt), nil
+}
+
+// import "lib.si" as lib;
+func (p *Parser) parseImportStmt() (ast.Stmt, error) {
+	path, err := p.consume(tokens.STRING, "expect module path after import")
+	if err != nil {
+		return nil, err
+	}
+
+	var alias *tokens.Token
+	if p.match(tokens.AS) {
+		alias, err = p.consume(tokens.IDENT, "expect module name after as")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.consume(tokens.SEMICOLON, "expect `;` after import"); err != nil {
+		return nil, err
+	}
+
+	return ast.NewImportStmt(path, alias), nil
+}
+
+// from "lib.si" import add, sub;
+func (p *Parser) parseFromImportStmt() (ast.Stmt, error) {
+	path, err := p.consume(tokens.STRING, "expect module path after from")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(tokens.IMPORT, "expect import after module path"); err != nil {
+		return nil, err
+	}
+
+	var names []*tokens.Token
+	for {
+		name, err := p.consume(tokens.IDENT, "expect name to import")
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+
+		if !p.match(tokens.COMMA) {
+			break
+		}
+	}
+
+	if _, err := p.consume(tokens.SEMICOLON, "expect `;` after import"); err != nil {
+		return nil, err
+	}
+
+	return ast.NewFromImportStmt(path, names), nil
+}
+
 func (p *Parser) parseLetStmt() (ast.Stmt, error) {
 	identToken, err := p.consume(tokens.IDENT, "expect identifier name")
 	if err != nil {
@@ -74,6 +178,29 @@ func (p *Parser) parseLetStmt() (ast.Stmt, error) {
 	return ast.NewLetStmt(identToken, initExpr), nil
 }
 
+// const NAME = expr;
+func (p *Parser) parseConstStmt() (ast.Stmt, error) {
+	identToken, err := p.consume(tokens.IDENT, "expect identifier name")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(tokens.ASSIGN, "expect `=` after constant name"); err != nil {
+		return nil, err
+	}
+
+	initExpr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(tokens.SEMICOLON, "expect `;` after constant value"); err != nil {
+		return nil, err
+	}
+
+	return ast.NewConstStmt(identToken, initExpr), nil
+}
+
 func (p *Parser) parseClassStmt() (*ast.ClassStmt, error) {
 	className, err := p.consume(tokens.IDENT, "expect class name")
 	if err != nil {
@@ -112,40 +239,90 @@ func (p *Parser) function() (*ast.Function, error) {
 		return nil, err
 	}
 
+	params, defaults, rest, err := p.parameters()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(tokens.LBRACE, "Expect `{` before function body."); err != nil {
+		return nil, err
+	}
+
+	outerYielded, outerLoops := p.yielded, p.loopDepth
+	p.fnDepth++
+	p.yielded, p.loopDepth = false, 0
+	body, err := p.block()
+	p.fnDepth--
+	generator := p.yielded
+	p.yielded, p.loopDepth = outerYielded, outerLoops
+	if err != nil {
+		return nil, err
+	}
+
+	fn := ast.NewFunctionStmt(name, params, body)
+	fn.Generator = generator
+	fn.Defaults = defaults
+	fn.Rest = rest
+	return fn, nil
+}
+
+// parameters parses the parameters of a function until `)`: a, b = 10, ...rest
+// the parameters after one with default value must have default values too, and the rest parameter is the last one
+func (p *Parser) parameters() ([]*tokens.Token, []ast.Expression, *tokens.Token, error) {
 	var params []*tokens.Token
-	if !p.check(tokens.RPARENT) {
-		ident, err := p.consume(tokens.IDENT, "Expect parameter name.")
-		if err != nil {
-			return nil, err
+	var defaults []ast.Expression
+	var rest *tokens.Token
+	hasDefault := false
+
+	for !p.check(tokens.RPARENT) {
+		if len(params) > 0 || rest != nil {
+			if _, err := p.consume(tokens.COMMA, "Expect `,` between parameters."); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		if len(params) >= maxParameters {
+			return nil, nil, nil, fmt.Errorf("cannot have more than %d parameters", maxParameters)
+		}
+		if rest != nil {
+			return nil, nil, nil, fmt.Errorf("rest parameter %s must be the last parameter", rest.Literal)
 		}
-		params = append(params, ident)
 
-		for p.match(tokens.COMMA) {
-			if len(params) > 8 {
-				return nil, fmt.Errorf("cannot have more than 8 parameters")
+		if p.match(tokens.ELLIPSIS) {
+			ident, err := p.consume(tokens.IDENT, "Expect parameter name after `...`.")
+			if err != nil {
+				return nil, nil, nil, err
 			}
+			rest = ident
+			continue
+		}
 
-			ident, err = p.consume(tokens.IDENT, "Expect parameter name.")
-			if err != nil {
-				return nil, err
+		ident, err := p.consume(tokens.IDENT, "Expect parameter name.")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		var value ast.Expression
+		if p.match(tokens.ASSIGN) {
+			if value, err = p.parseExpr(); err != nil {
+				return nil, nil, nil, err
 			}
-			params = append(params, ident)
+			hasDefault = true
+		} else if hasDefault {
+			return nil, nil, nil, fmt.Errorf("parameter %s without default value follows a parameter with default value", ident.Literal)
 		}
-	}
 
-	if _, err := p.consume(tokens.RPARENT, "Expect `)` after parameters"); err != nil {
-		return nil, err
+		params = append(params, ident)
+		defaults = append(defaults, value)
 	}
 
-	if _, err := p.consume(tokens.LBRACE, "Expect `{` before function body."); err != nil {
-		return nil, err
+	if _, err := p.consume(tokens.RPARENT, "Expect `)` after parameters"); err != nil {
+		return nil, nil, nil, err
 	}
 
-	body, err := p.block()
-	if err != nil {
-		return nil, err
+	if !hasDefault {
+		defaults = nil
 	}
-	return ast.NewFunctionStmt(name, params, body), nil
+	return params, defaults, rest, nil
 }
 
 func (p *Parser) parseReturnStmt() (ast.Stmt, error) {
@@ -153,7 +330,7 @@ func (p *Parser) parseReturnStmt() (ast.Stmt, error) {
 
 	var value ast.Expression
 	var err error
-	if !p.match(tokens.SEMICOLON) {
+	if !p.check(tokens.SEMICOLON) {
 		value, err = p.parseExpr()
 		if err != nil {
 			return nil, err
@@ -167,6 +344,27 @@ func (p *Parser) parseReturnStmt() (ast.Stmt, error) {
 	return ast.NewReturnStmt(kw, value), nil
 }
 
+func (p *Parser) parseYieldStmt() (ast.Stmt, error) {
+	kw := p.previous()
+	if p.fnDepth == 0 {
+		return nil, fmt.Errorf("can not yield outside a function")
+	}
+	p.yielded = true
+
+	var value ast.Expression
+	if !p.check(tokens.SEMICOLON) {
+		var err error
+		if value, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.consume(tokens.SEMICOLON, "expect `;` after yield value"); err != nil {
+		return nil, err
+	}
+	return ast.NewYieldStmt(kw, value), nil
+}
+
 func (p *Parser) statement() (ast.Stmt, error) {
 	switch {
 	case p.match(tokens.FOR):
@@ -175,6 +373,8 @@ func (p *Parser) statement() (ast.Stmt, error) {
 		return p.ifStatement()
 	case p.match(tokens.RETURN):
 		return p.parseReturnStmt()
+	case p.match(tokens.YIELD):
+		return p.parseYieldStmt()
 	case p.match(tokens.PRINT):
 		return p.printStatement()
 	case p.match(tokens.BREAK):
@@ -189,6 +389,14 @@ func (p *Parser) statement() (ast.Stmt, error) {
 			return nil, err
 		}
 		return block, nil
+	case p.match(tokens.MATCH):
+		// match statement does not need `;`, and the next statement can not continue it like a call
+		m, err := p.parseMatch()
+		if err != nil {
+			return nil, err
+		}
+		p.match(tokens.SEMICOLON)
+		return ast.NewExpressionStmt(m), nil
 	}
 
 	return p.expressionStatement()
@@ -199,6 +407,10 @@ func (p *Parser) forStatement() (ast.Stmt, error) {
 		return nil, err
 	}
 
+	if p.checkNext(tokens.IDENT, tokens.IN) || p.checkNext(tokens.IDENT, tokens.COMMA, tokens.IDENT, tokens.IN) {
+		return p.forInStatement()
+	}
+
 	var initializer ast.Stmt
 	var err error
 
@@ -225,7 +437,7 @@ func (p *Parser) forStatement() (ast.Stmt, error) {
 	}
 
 	var increment ast.Expression
-	if !p.check(tokens.SEMICOLON) {
+	if !p.check(tokens.RPARENT) {
 		increment, err = p.parseExpr()
 		if err != nil {
 			return nil, err
@@ -235,26 +447,42 @@ func (p *Parser) forStatement() (ast.Stmt, error) {
 		return nil, err
 	}
 
-	body, err := p.statement()
+	body, err := p.loopBody()
 	if err != nil {
 		return nil, err
 	}
 
-	if increment != nil {
-		body = ast.NewBlockStmt([]ast.Stmt{body, ast.NewExpressionStmt(increment)})
+	return ast.NewForStmt(initializer, cond, increment, body), nil
+}
+
+// for (x in xs) body, for (i, x in xs) body
+func (p *Parser) forInStatement() (ast.Stmt, error) {
+	var key *tokens.Token
+	value := p.advance()
+	if p.match(tokens.COMMA) {
+		key = value
+		value = p.advance()
+	}
+
+	if _, err := p.consume(tokens.IN, "Expect `in` after loop variables."); err != nil {
+		return nil, err
 	}
 
-	if cond == nil {
-		cond, _ = ast.NewLiteral1(true)
+	iterable, err := p.parseExpr()
+	if err != nil {
+		return nil, err
 	}
 
-	body = ast.NewWhileStmt(cond, body)
+	if _, err = p.consume(tokens.RPARENT, "Expect `)` after for clauses."); err != nil {
+		return nil, err
+	}
 
-	if initializer != nil {
-		body = ast.NewBlockStmt([]ast.Stmt{initializer, body})
+	body, err := p.loopBody()
+	if err != nil {
+		return nil, err
 	}
 
-	return body, nil
+	return ast.NewForInStmt(key, value, iterable, body), nil
 }
 
 func (p *Parser) ifStatement() (ast.Stmt, error) {
@@ -317,6 +545,10 @@ func (p *Parser) printStatement() (ast.Stmt, error) {
 }
 
 func (p *Parser) parseBreakStmt() (ast.Stmt, error) {
+	if p.loopDepth == 0 {
+		return nil, fmt.Errorf("can not break outside a loop")
+	}
+
 	if _, err := p.consume(tokens.SEMICOLON, `Expect ";" after break`); err != nil {
 		return nil, err
 	}
@@ -324,6 +556,13 @@ func (p *Parser) parseBreakStmt() (ast.Stmt, error) {
 	return ast.NewBreakStmt(), nil
 }
 
+// loopBody parses the body of a loop, which can break the loop
+func (p *Parser) loopBody() (ast.Stmt, error) {
+	p.loopDepth++
+	defer func() { p.loopDepth-- }()
+	return p.statement()
+}
+
 // whileStatement
 func (p *Parser) whileStatement() (ast.Stmt, error) {
 	if _, err := p.consume(tokens.LPRARENT, `expect "(" after 'while'.`); err != nil {
@@ -338,7 +577,7 @@ func (p *Parser) whileStatement() (ast.Stmt, error) {
 		return nil, err
 	}
 
-	body, err := p.statement()
+	body, err := p.loopBody()
 	if err != nil {
 		return nil, err
 	}
@@ -388,7 +627,7 @@ func (p *Parser) parseExpr() (ast.Expression, error) {
 }
 
 func (p *Parser) assignment() (ast.Expression, error) {
-	exp, err := p.or()
+	exp, err := p.ternary()
 	if err != nil {
 		return nil, err
 	}
@@ -415,6 +654,77 @@ func (p *Parser) assignment() (ast.Expression, error) {
 		return nil, fmt.Errorf("invalid assignment: %v", equals)
 	}
 
+	if p.match(tokens.PLUSEQ, tokens.MINUSEQ, tokens.ASTERISKEQ, tokens.SLASHEQ) {
+		op := p.previous()
+		value, err := p.assignment()
+		if err != nil {
+			return nil, err
+		}
+
+		if !isAssignable(exp) {
+			return nil, fmt.Errorf("invalid assignment: %s", op.Literal)
+		}
+		return ast.NewCompoundAssign(exp, op, value), nil
+	}
+
+	return exp, nil
+}
+
+// isAssignable reports whether expr can be the target of +=, ++ and the others
+func isAssignable(expr ast.Expression) bool {
+	switch v := expr.(type) {
+	case *ast.Identifier, *ast.SliceAccess:
+		return true
+	case *ast.Get:
+		return !v.Optional
+	}
+	return false
+}
+
+// ternary: cond ? then : else, it is right associative
+func (p *Parser) ternary() (ast.Expression, error) {
+	cond, err := p.coalesce()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.match(tokens.QUESTION) {
+		return cond, nil
+	}
+
+	then, err := p.assignment()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(tokens.COLON, "expect `:` in conditional expression"); err != nil {
+		return nil, err
+	}
+
+	els, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewTernary(cond, then, els), nil
+}
+
+// coalesce: a ?? b, b is evaluated only when a is null
+func (p *Parser) coalesce() (ast.Expression, error) {
+	exp, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.match(tokens.DQUESTION) {
+		op := p.previous()
+		right, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		exp = ast.NewLogical(exp, right, op)
+	}
+
 	return exp, nil
 }
 
@@ -518,7 +828,7 @@ func (p *Parser) factor() (ast.Expression, error) {
 		return nil, err
 	}
 
-	for p.match(tokens.SLASH, tokens.ASTERISK) {
+	for p.match(tokens.SLASH, tokens.ASTERISK, tokens.PERCENT) {
 		op := p.previous()
 		right, err := p.pow()
 		if err != nil {
@@ -549,6 +859,19 @@ func (p *Parser) pow() (ast.Expression, error) {
 }
 
 func (p *Parser) unary() (ast.Expression, error) {
+	if p.match(tokens.DPlus, tokens.DMinus) {
+		op := p.previous()
+		right, err := p.call()
+		if err != nil {
+			return nil, err
+		}
+
+		if !isAssignable(right) {
+			return nil, fmt.Errorf("invalid operand of %s", op.Literal)
+		}
+		return ast.NewPrefixDExp(right, op), nil
+	}
+
 	if p.match(tokens.BANG, tokens.MINUS) {
 		op := p.previous()
 		right, err := p.unary()
@@ -568,29 +891,44 @@ func (p *Parser) call() (ast.Expression, error) {
 		return nil, err
 	}
 
-	switch {
-	case p.match(tokens.DPlus, tokens.DMinus):
-		expr = ast.NewDExp(expr, p.previous())
-	case p.match(tokens.LSQBRACKET):
-		expr, err = p.parseSliceAccess(expr)
-	default:
-		for {
-			if p.match(tokens.LPRARENT) {
-				expr, err = p.finishCall(expr)
-			} else if p.match(tokens.DOT) {
-				name, err := p.consume(tokens.IDENT, "Expect property name after .")
-				if err != nil {
-					return nil, err
-				}
-				expr = ast.NewGet(expr, name)
-			} else {
-				break
+	optional := false
+	for {
+		if p.match(tokens.LPRARENT) {
+			expr, err = p.finishCall(expr)
+		} else if p.match(tokens.DOT) {
+			name, err := p.consume(tokens.IDENT, "Expect property name after .")
+			if err != nil {
+				return nil, err
+			}
+			expr = ast.NewGet(expr, name)
+		} else if p.match(tokens.QDOT) {
+			name, err := p.consume(tokens.IDENT, "Expect property name after ?.")
+			if err != nil {
+				return nil, err
 			}
+			expr = ast.NewOptionalGet(expr, name)
+			optional = true
+		} else if p.match(tokens.LSQBRACKET) {
+			expr, err = p.parseSliceAccess(expr)
+		} else {
+			break
+		}
+
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	if err != nil {
-		return nil, err
+	if optional {
+		expr = ast.NewOptionalChain(expr)
+	}
+
+	if p.match(tokens.DPlus, tokens.DMinus) {
+		op := p.previous()
+		if !isAssignable(expr) {
+			return nil, fmt.Errorf("invalid operand of %s", op.Literal)
+		}
+		expr = ast.NewDExp(expr, op)
 	}
 
 	return expr, nil
@@ -602,9 +940,9 @@ func (p *Parser) primary() (ast.Expression, error) {
 		return ast.NewLiteral(p.previous()), nil
 	case p.match(tokens.TRUE):
 		return ast.NewLiteral(p.previous()), nil
-	case p.match(tokens.NIL):
+	case p.match(tokens.NULL):
 		return ast.NewLiteral(p.previous()), nil
-	case p.match(tokens.INTEGER, tokens.STRING):
+	case p.match(tokens.INTEGER, tokens.FLOAT, tokens.STRING):
 		return ast.NewLiteral(p.previous()), nil
 	case p.match(tokens.IDENT):
 		return ast.NewIdentifier(p.previous()), nil
@@ -612,18 +950,184 @@ func (p *Parser) primary() (ast.Expression, error) {
 		return ast.NewThisExpr(p.previous()), nil
 	case p.match(tokens.LSQBRACKET):
 		return p.parseSlice()
+	case p.match(tokens.LBRACE):
+		return p.parseMapLiteral()
+	case p.match(tokens.MATCH):
+		return p.parseMatch()
 	case p.match(tokens.LPRARENT):
 		exp, err := p.parseExpr()
 		if err != nil {
 			return nil, err
 		}
 
+		if _, err := p.consume(tokens.RPARENT, "Expect ) after expression"); err != nil {
+			return nil, err
+		}
 		return ast.NewGrouping(exp), nil
 	}
 
 	return nil, fmt.Errorf("unknow expr")
 }
 
+// match (expr) { 1, 2 => "small", 3..10 => { ... }, x if x > 100 => "huge", _ => "large" }
+func (p *Parser) parseMatch() (*ast.Match, error) {
+	if _, err := p.consume(tokens.LPRARENT, "expect `(` after match"); err != nil {
+		return nil, err
+	}
+
+	subject, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(tokens.RPARENT, "expect `)` after match value"); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(tokens.LBRACE, "expect `{` before match arms"); err != nil {
+		return nil, err
+	}
+
+	var arms []*ast.MatchArm
+	for !p.check(tokens.RBRACE) && !p.isAtEnd() {
+		arm, err := p.parseMatchArm()
+		if err != nil {
+			return nil, err
+		}
+		arms = append(arms, arm)
+	}
+
+	if _, err := p.consume(tokens.RBRACE, "expect `}` after match arms"); err != nil {
+		return nil, err
+	}
+
+	if err := checkMatchArms(arms); err != nil {
+		return nil, err
+	}
+	return ast.NewMatch(subject, arms), nil
+}
+
+func (p *Parser) parseMatchArm() (*ast.MatchArm, error) {
+	var patterns []*ast.MatchPattern
+	for {
+		pattern, err := p.parseMatchPattern()
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+
+		if !p.match(tokens.COMMA) {
+			break
+		}
+	}
+
+	var guard ast.Expression
+	if p.match(tokens.IF) {
+		var err error
+		if guard, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.consume(tokens.ARROW, "expect `=>` after match pattern"); err != nil {
+		return nil, err
+	}
+
+	var body ast.Stmt
+	if p.match(tokens.LBRACE) {
+		block, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		body = block
+		p.match(tokens.COMMA)
+	} else {
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		body = ast.NewExpressionStmt(value)
+
+		if !p.check(tokens.RBRACE) {
+			if _, err := p.consume(tokens.COMMA, "expect `,` after match arm"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ast.NewMatchArm(patterns, guard, body), nil
+}
+
+func (p *Parser) parseMatchPattern() (*ast.MatchPattern, error) {
+	if p.match(tokens.IDENT) {
+		return ast.NewBindingPattern(p.previous()), nil
+	}
+
+	low, err := p.patternLiteral()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.match(tokens.DOTDOT, tokens.DOTDOTEQ) {
+		inclusive := p.previous().TkType == tokens.DOTDOTEQ
+		high, err := p.patternLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewRangePattern(low, high, inclusive), nil
+	}
+
+	return ast.NewLiteralPattern(low), nil
+}
+
+// patternLiteral parses the literal in a pattern: 1, -1, 1.5, "x", true, false and null
+func (p *Parser) patternLiteral() (*ast.Literal, error) {
+	if p.match(tokens.MINUS) {
+		switch {
+		case p.match(tokens.INTEGER):
+			return ast.NewLiteral(tokens.NewIntegerToken(-p.previous().Value.(int64))), nil
+		case p.match(tokens.FLOAT):
+			return ast.NewLiteral(tokens.NewFloatToken(-p.previous().Value.(float64))), nil
+		}
+		return nil, fmt.Errorf("expect number after `-` in match pattern")
+	}
+
+	if p.match(tokens.INTEGER, tokens.FLOAT, tokens.STRING, tokens.TRUE, tokens.FALSE, tokens.NULL) {
+		return ast.NewLiteral(p.previous()), nil
+	}
+	return nil, fmt.Errorf("expect literal, range or name as match pattern")
+}
+
+// checkMatchArms requires a default arm(or both true and false), and the default arm must be the last one
+func checkMatchArms(arms []*ast.MatchArm) error {
+	bools := make(map[bool]bool)
+	for idx, arm := range arms {
+		if arm.Guard != nil {
+			continue
+		}
+
+		for _, pattern := range arm.Patterns {
+			if pattern.Kind == ast.PatternBinding {
+				if idx != len(arms)-1 {
+					return fmt.Errorf("unreachable match arm after the default arm")
+				}
+				return nil
+			}
+
+			if pattern.Kind == ast.PatternLiteral {
+				if b, ok := pattern.Value.Value.Value.(bool); ok {
+					bools[b] = true
+				}
+			}
+		}
+	}
+
+	if bools[true] && bools[false] {
+		return nil
+	}
+	return fmt.Errorf("match is not exhaustive, add a default arm: `_ => ...`")
+}
+
 func (p *Parser) parseSlice() (ast.Expression, error) {
 	elements := make([]ast.Expression, 0, 1)
 	// empty slice
@@ -649,38 +1153,110 @@ func (p *Parser) parseSlice() (ast.Expression, error) {
 	return ast.NewSlice(elements), nil
 }
 
+// parseMapLiteral parse {key: value, ...}
+func (p *Parser) parseMapLiteral() (ast.Expression, error) {
+	pairs := make([]*ast.MapPair, 0)
+	for !p.check(tokens.RBRACE) {
+		key, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := p.consume(tokens.COLON, "Expect ':' after map key"); err != nil {
+			return nil, err
+		}
+
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, &ast.MapPair{Key: key, Value: value})
+
+		if !p.match(tokens.COMMA) {
+			break
+		}
+	}
+
+	if _, err := p.consume(tokens.RBRACE, "Expect '}' after map pairs"); err != nil {
+		return nil, err
+	}
+	return ast.NewMapLiteral(pairs), nil
+}
+
+// parseSliceAccess parse slice[idx] and slice[low:high:step]
 func (p *Parser) parseSliceAccess(expr ast.Expression) (ast.Expression, error) {
-	idx, err := p.parseExpr() // get index value
+	var idx ast.Expression
+	var err error
+	if !p.check(tokens.COLON) {
+		idx, err = p.parseExpr() // get index value
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !p.match(tokens.COLON) {
+		if idx == nil {
+			return nil, fmt.Errorf("Expect index in []")
+		}
+		if _, err := p.consume(tokens.RSQBRACKET, "Expect ] after index"); err != nil {
+			return nil, err
+		}
+
+		return ast.NewSliceAccess(expr, idx), nil
+	}
+
+	high, err := p.sliceRangePart()
 	if err != nil {
 		return nil, err
 	}
-	if _, err := p.consume(tokens.RSQBRACKET, "Expect ] after index"); err != nil {
+
+	var step ast.Expression
+	if p.match(tokens.COLON) {
+		step, err = p.sliceRangePart()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.consume(tokens.RSQBRACKET, "Expect ] after slice range"); err != nil {
 		return nil, err
 	}
 
-	return ast.NewSliceAccess(expr, idx), nil
+	return ast.NewSliceRange(expr, idx, high, step), nil
+}
+
+func (p *Parser) sliceRangePart() (ast.Expression, error) {
+	if p.check(tokens.COLON) || p.check(tokens.RSQBRACKET) {
+		return nil, nil
+	}
+
+	return p.parseExpr()
 }
 
 func (p *Parser) finishCall(callee ast.Expression) (ast.Expression, error) {
 	arguments := make([]ast.Expression, 0, 10)
-	if !p.check(tokens.RPARENT) {
-		expr, err := p.parseExpr()
-		if err != nil {
-			return nil, err
-		}
-		arguments = append(arguments, expr)
-
-		for p.check(tokens.COMMA) {
+	keywords := make(map[string]bool)
+	for !p.check(tokens.RPARENT) {
+		if len(arguments) > 0 {
 			if _, err := p.consume(tokens.COMMA, "expect comma after ("); err != nil {
 				return nil, err
 			}
+		}
 
-			expr, err := p.parseExpr()
-			if err != nil {
-				return nil, err
+		arg, err := p.argument()
+		if err != nil {
+			return nil, err
+		}
+
+		if kw, ok := arg.(*ast.KeywordArg); ok {
+			if keywords[kw.Name.Literal] {
+				return nil, fmt.Errorf("duplicate keyword argument: %s", kw.Name.Literal)
 			}
-			arguments = append(arguments, expr)
+			keywords[kw.Name.Literal] = true
+		} else if len(keywords) > 0 {
+			return nil, fmt.Errorf("positional argument follows keyword argument")
 		}
+		arguments = append(arguments, arg)
 	}
 
 	if _, err := p.consume(tokens.RPARENT, "Expect ) after call"); err != nil {
@@ -690,6 +1266,29 @@ func (p *Parser) finishCall(callee ast.Expression) (ast.Expression, error) {
 	return ast.NewCall(callee, arguments), nil
 }
 
+// argument parses one argument of a call: expr, ...expr or name: expr
+func (p *Parser) argument() (ast.Expression, error) {
+	if p.match(tokens.ELLIPSIS) {
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewSpread(expr), nil
+	}
+
+	if p.checkNext(tokens.IDENT, tokens.COLON) {
+		name := p.advance()
+		p.advance()
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewKeywordArg(name, value), nil
+	}
+
+	return p.parseExpr()
+}
+
 func (p *Parser) consume(tkType tokens.TokenType, msg string) (*tokens.Token, error) {
 	if p.check(tkType) {
 		return p.advance(), nil
@@ -716,6 +1315,17 @@ func (p *Parser) check(tkType tokens.TokenType) bool {
 	return p.peek().TkType == tkType
 }
 
+// checkNext reports whether the next tokens are of the types, without consuming them
+func (p *Parser) checkNext(tkTypes ...tokens.TokenType) bool {
+	for idx, t := range tkTypes {
+		pos := p.current + idx
+		if pos >= len(p.tokens) || p.tokens[pos].TkType != t {
+			return false
+		}
+	}
+	return true
+}
+
 func (p *Parser) advance() *tokens.Token {
 	if !p.isAtEnd() {
 		p.current++
@@ -136,8 +136,13 @@ func TestParseFor(t *testing.T) {
 						}),
 					),
 				}),
-				program.Stmts[0],
+				program.Stmts[0].(*ast.ForStmt).Loop,
 			)
+
+			forStmt := program.Stmts[0].(*ast.ForStmt)
+			assert.Equal(t, ast.NewExpressionStmt(ast.NewAssign(tokens.NewIdentToken("i"), zeroLiteral)), forStmt.Init)
+			assert.Equal(t, ast.NewIdentifier1("i"), forStmt.Condition.(*ast.Binary).Left)
+			assert.IsType(t, &ast.Assign{}, forStmt.Increment)
 		}
 	}
 }
@@ -182,6 +187,16 @@ func TestWhileStmt(t *testing.T) {
 	}
 }
 
+func TestParseForWithoutIncrement(t *testing.T) {
+	for _, input := range []string{`for (;;) {}`, `for (let i = 0; i < 3;) { i++; }`} {
+		tokenList, err := lexer.TokensFromInput(input)
+		assert.Nil(t, err)
+
+		_, err = NewParser(tokenList).ParseProgram()
+		assert.Nil(t, err, input)
+	}
+}
+
 func TestAssign(t *testing.T) {
 	input := `
 	let a = 1;
@@ -561,3 +576,396 @@ func TestParseSliceAccessStmt(t *testing.T) {
 		}
 	}
 }
+
+func TestParseSliceRange(t *testing.T) {
+	input := `
+	arr[1:3];
+	arr[:2];
+	arr[-2:];
+	arr[::-1];
+	arr[0][1:];
+	`
+	tokenList, err := lexer.TokensFromInput(input)
+	assert.Nil(t, err)
+
+	arr := ast.NewIdentifier1("arr")
+	zeroLiteral, _ := ast.NewLiteral1(0)
+	oneLiteral, _ := ast.NewLiteral1(1)
+	twoLiteral, _ := ast.NewLiteral1(2)
+	threeLiteral, _ := ast.NewLiteral1(3)
+	minus := tokens.NewToken(tokens.MINUS, "-", "-")
+
+	p := NewParser(tokenList)
+	program, err := p.ParseProgram()
+	if assert.Nil(t, err) && assert.Equal(t, 5, len(program.Stmts)) {
+		assert.Equal(t, ast.NewExpressionStmt(
+			ast.NewSliceRange(arr, oneLiteral, threeLiteral, nil),
+		), program.Stmts[0])
+
+		assert.Equal(t, ast.NewExpressionStmt(
+			ast.NewSliceRange(arr, nil, twoLiteral, nil),
+		), program.Stmts[1])
+
+		assert.Equal(t, ast.NewExpressionStmt(
+			ast.NewSliceRange(arr, ast.NewUnary(minus, twoLiteral), nil, nil),
+		), program.Stmts[2])
+
+		assert.Equal(t, ast.NewExpressionStmt(
+			ast.NewSliceRange(arr, nil, nil, ast.NewUnary(minus, oneLiteral)),
+		), program.Stmts[3])
+
+		assert.Equal(t, ast.NewExpressionStmt(
+			ast.NewSliceRange(ast.NewSliceAccess(arr, zeroLiteral), oneLiteral, nil, nil),
+		), program.Stmts[4])
+	}
+}
+
+func TestParseGrouping(t *testing.T) {
+	tokenList, err := lexer.TokensFromInput(`(1 + 2) * 3;`)
+	assert.Nil(t, err)
+
+	oneLiteral, _ := ast.NewLiteral1(1)
+	twoLiteral, _ := ast.NewLiteral1(2)
+	threeLiteral, _ := ast.NewLiteral1(3)
+
+	program, err := NewParser(tokenList).ParseProgram()
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(program.Stmts)) {
+		assert.Equal(t, ast.NewExpressionStmt(
+			ast.NewBinary(
+				ast.NewGrouping(ast.NewBinary(oneLiteral, twoLiteral, tokens.NewToken(tokens.PLUS, "+", "+"))),
+				threeLiteral,
+				tokens.NewToken(tokens.ASTERISK, "*", "*"),
+			),
+		), program.Stmts[0])
+	}
+
+	tokenList, err = lexer.TokensFromInput(`(1 + 2 * 3;`)
+	assert.Nil(t, err)
+
+	_, err = NewParser(tokenList).ParseProgram()
+	assert.EqualError(t, err, "Expect ) after expression")
+}
+
+func TestParseMapLiteral(t *testing.T) {
+	tokenList, err := lexer.TokensFromInput(`let m = {"a": 1, 2: "b",};`)
+	assert.Nil(t, err)
+
+	aLiteral, _ := ast.NewLiteral1("a")
+	oneLiteral, _ := ast.NewLiteral1(1)
+	twoLiteral, _ := ast.NewLiteral1(2)
+	bLiteral, _ := ast.NewLiteral1("b")
+
+	program, err := NewParser(tokenList).ParseProgram()
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(program.Stmts)) {
+		assert.Equal(t, ast.NewLetStmt(
+			tokens.NewIdentToken("m"),
+			ast.NewMapLiteral([]*ast.MapPair{
+				{Key: aLiteral, Value: oneLiteral},
+				{Key: twoLiteral, Value: bLiteral},
+			}),
+		), program.Stmts[0])
+	}
+}
+
+func TestParseImport(t *testing.T) {
+	tokenList, err := lexer.TokensFromInput(`
+	import "lib.si" as lib;
+	from "lib.si" import a, b;
+	export let x = 1;
+	`)
+	assert.Nil(t, err)
+
+	oneLiteral, _ := ast.NewLiteral1(1)
+	path := tokens.NewStringToken("lib.si")
+
+	program, err := NewParser(tokenList).ParseProgram()
+	if assert.Nil(t, err) {
+		assert.Equal(t, []ast.Stmt{
+			ast.NewImportStmt(path, tokens.NewIdentToken("lib")),
+			ast.NewFromImportStmt(path, []*tokens.Token{
+				tokens.NewIdentToken("a"),
+				tokens.NewIdentToken("b"),
+			}),
+			ast.NewExportStmt(ast.NewLetStmt(tokens.NewIdentToken("x"), oneLiteral)),
+		}, program.Stmts)
+	}
+
+	tokenList, err = lexer.TokensFromInput(`fn f() { export let x = 1; }`)
+	assert.Nil(t, err)
+
+	_, err = NewParser(tokenList).ParseProgram()
+	assert.EqualError(t, err, "export is only allowed at the top level")
+}
+
+func TestParseConst(t *testing.T) {
+	tokenList, err := lexer.TokensFromInput(`const x = 1;`)
+	assert.Nil(t, err)
+
+	oneLiteral, _ := ast.NewLiteral1(1)
+
+	program, err := NewParser(tokenList).ParseProgram()
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(program.Stmts)) {
+		assert.Equal(t, ast.NewConstStmt(tokens.NewIdentToken("x"), oneLiteral), program.Stmts[0])
+	}
+
+	tokenList, err = lexer.TokensFromInput(`const x;`)
+	assert.Nil(t, err)
+
+	_, err = NewParser(tokenList).ParseProgram()
+	assert.EqualError(t, err, "expect `=` after constant name")
+}
+
+func TestParseMatch(t *testing.T) {
+	tokenList, err := lexer.TokensFromInput(`match (x) { 1, -2 => "a", 3..=5 => { y; } n if n > 9 => n, _ => null }`)
+	assert.Nil(t, err)
+
+	oneLiteral, _ := ast.NewLiteral1(1)
+	minusTwoLiteral, _ := ast.NewLiteral1(-2)
+	threeLiteral, _ := ast.NewLiteral1(3)
+	fiveLiteral, _ := ast.NewLiteral1(5)
+	nineLiteral, _ := ast.NewLiteral1(9)
+	aLiteral, _ := ast.NewLiteral1("a")
+
+	program, err := NewParser(tokenList).ParseProgram()
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(program.Stmts)) {
+		assert.Equal(t, ast.NewExpressionStmt(ast.NewMatch(
+			ast.NewIdentifier1("x"),
+			[]*ast.MatchArm{
+				ast.NewMatchArm(
+					[]*ast.MatchPattern{ast.NewLiteralPattern(oneLiteral), ast.NewLiteralPattern(minusTwoLiteral)},
+					nil,
+					ast.NewExpressionStmt(aLiteral),
+				),
+				ast.NewMatchArm(
+					[]*ast.MatchPattern{ast.NewRangePattern(threeLiteral, fiveLiteral, true)},
+					nil,
+					ast.NewBlockStmt([]ast.Stmt{ast.NewExpressionStmt(ast.NewIdentifier1("y"))}),
+				),
+				ast.NewMatchArm(
+					[]*ast.MatchPattern{ast.NewBindingPattern(tokens.NewIdentToken("n"))},
+					ast.NewBinary(ast.NewIdentifier1("n"), nineLiteral, tokens.NewToken(tokens.GT, ">", ">")),
+					ast.NewExpressionStmt(ast.NewIdentifier1("n")),
+				),
+				ast.NewMatchArm(
+					[]*ast.MatchPattern{ast.NewBindingPattern(tokens.NewIdentToken("_"))},
+					nil,
+					ast.NewExpressionStmt(ast.NewLiteral(tokens.LookupTokenByIdent(tokens.KWNull))),
+				),
+			},
+		)), program.Stmts[0])
+	}
+
+	errTests := []struct {
+		input   string
+		wantErr string
+	}{
+		{`match (x) { 1 => 2 }`, "match is not exhaustive, add a default arm: `_ => ...`"},
+		{`match (x) { n if n > 1 => 2 }`, "match is not exhaustive, add a default arm: `_ => ...`"},
+		{`match (x) { _ => 1, 2 => 3 }`, "unreachable match arm after the default arm"},
+		{`match (x) { 1 => 2 _ => 3 }`, "expect `,` after match arm"},
+	}
+	for _, tt := range errTests {
+		tokenList, err := lexer.TokensFromInput(tt.input)
+		assert.Nil(t, err)
+
+		_, err = NewParser(tokenList).ParseProgram()
+		assert.EqualError(t, err, tt.wantErr)
+	}
+}
+
+func TestParseTernary(t *testing.T) {
+	tokenList, err := lexer.TokensFromInput(`a ?? b ? c : d ? e?.f : g;`)
+	assert.Nil(t, err)
+
+	program, err := NewParser(tokenList).ParseProgram()
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(program.Stmts)) {
+		assert.Equal(t, ast.NewExpressionStmt(ast.NewTernary(
+			ast.NewLogical(ast.NewIdentifier1("a"), ast.NewIdentifier1("b"), tokens.NewToken(tokens.DQUESTION, "??", "??")),
+			ast.NewIdentifier1("c"),
+			ast.NewTernary(
+				ast.NewIdentifier1("d"),
+				ast.NewOptionalChain(ast.NewOptionalGet(ast.NewIdentifier1("e"), tokens.NewIdentToken("f"))),
+				ast.NewIdentifier1("g"),
+			),
+		)), program.Stmts[0])
+	}
+
+	tokenList, err = lexer.TokensFromInput(`a ? b;`)
+	assert.Nil(t, err)
+
+	_, err = NewParser(tokenList).ParseProgram()
+	assert.EqualError(t, err, "expect `:` in conditional expression")
+}
+
+func TestParseCompoundAssign(t *testing.T) {
+	tokenList, err := lexer.TokensFromInput(`xs[0] += ++i * o.n--;`)
+	assert.Nil(t, err)
+
+	zeroLiteral, _ := ast.NewLiteral1(0)
+
+	program, err := NewParser(tokenList).ParseProgram()
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(program.Stmts)) {
+		assert.Equal(t, ast.NewExpressionStmt(ast.NewCompoundAssign(
+			ast.NewSliceAccess(ast.NewIdentifier1("xs"), zeroLiteral),
+			tokens.NewToken(tokens.PLUSEQ, "+=", "+="),
+			ast.NewBinary(
+				ast.NewPrefixDExp(ast.NewIdentifier1("i"), tokens.NewToken(tokens.DPlus, "++", "++")),
+				ast.NewDExp(
+					ast.NewGet(ast.NewIdentifier1("o"), tokens.NewIdentToken("n")),
+					tokens.NewToken(tokens.DMinus, "--", "--"),
+				),
+				tokens.NewToken(tokens.ASTERISK, "*", "*"),
+			),
+		)), program.Stmts[0])
+	}
+
+	errTests := []struct {
+		input   string
+		wantErr string
+	}{
+		{input: `1 += 2;`, wantErr: "invalid assignment: +="},
+		{input: `f() -= 2;`, wantErr: "invalid assignment: -="},
+		{input: `++1;`, wantErr: "invalid operand of ++"},
+		{input: `a?.b--;`, wantErr: "invalid operand of --"},
+	}
+
+	for _, tt := range errTests {
+		t.Run(tt.input, func(t *testing.T) {
+			tokenList, err := lexer.TokensFromInput(tt.input)
+			assert.Nil(t, err)
+
+			_, err = NewParser(tokenList).ParseProgram()
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseForIn(t *testing.T) {
+	tokenList, err := lexer.TokensFromInput(`for (i, x in xs) { x; } for (x in xs) x;`)
+	assert.Nil(t, err)
+
+	program, err := NewParser(tokenList).ParseProgram()
+	if assert.Nil(t, err) && assert.Equal(t, 2, len(program.Stmts)) {
+		assert.Equal(t, ast.NewForInStmt(
+			tokens.NewIdentToken("i"),
+			tokens.NewIdentToken("x"),
+			ast.NewIdentifier1("xs"),
+			ast.NewBlockStmt([]ast.Stmt{ast.NewExpressionStmt(ast.NewIdentifier1("x"))}),
+		), program.Stmts[0])
+		assert.Equal(t, ast.NewForInStmt(
+			nil,
+			tokens.NewIdentToken("x"),
+			ast.NewIdentifier1("xs"),
+			ast.NewExpressionStmt(ast.NewIdentifier1("x")),
+		), program.Stmts[1])
+	}
+}
+
+func TestParseYield(t *testing.T) {
+	tokenList, err := lexer.TokensFromInput(`fn gen() { fn inner() { return 1; } yield x; yield; } fn plain() { return 1; }`)
+	assert.Nil(t, err)
+
+	program, err := NewParser(tokenList).ParseProgram()
+	if assert.Nil(t, err) && assert.Equal(t, 2, len(program.Stmts)) {
+		gen := program.Stmts[0].(*ast.Function)
+		assert.True(t, gen.Generator)
+		assert.False(t, gen.Body.Statements[0].(*ast.Function).Generator)
+		assert.Equal(t, ast.NewIdentifier1("x"), gen.Body.Statements[1].(*ast.YieldStmt).Value)
+		assert.Nil(t, gen.Body.Statements[2].(*ast.YieldStmt).Value)
+		assert.False(t, program.Stmts[1].(*ast.Function).Generator)
+	}
+
+	tokenList, err = lexer.TokensFromInput(`yield 1;`)
+	assert.Nil(t, err)
+	_, err = NewParser(tokenList).ParseProgram()
+	assert.EqualError(t, err, "can not yield outside a function")
+}
+
+func TestParseParametersAndArguments(t *testing.T) {
+	tokenList, err := lexer.TokensFromInput(`fn f(a, b = 1, ...rest) {} f(1, ...xs, b: 2);`)
+	assert.Nil(t, err)
+
+	program, err := NewParser(tokenList).ParseProgram()
+	if assert.Nil(t, err) && assert.Equal(t, 2, len(program.Stmts)) {
+		fn := ast.NewFunctionStmt(
+			tokens.NewIdentToken("f"),
+			[]*tokens.Token{tokens.NewIdentToken("a"), tokens.NewIdentToken("b")},
+			ast.NewBlockStmt([]ast.Stmt{}),
+		)
+		fn.Defaults = []ast.Expression{nil, ast.NewLiteral(tokens.NewIntegerToken(1))}
+		fn.Rest = tokens.NewIdentToken("rest")
+		assert.Equal(t, fn, program.Stmts[0])
+
+		assert.Equal(t, ast.NewExpressionStmt(ast.NewCall(ast.NewIdentifier1("f"), []ast.Expression{
+			ast.NewLiteral(tokens.NewIntegerToken(1)),
+			ast.NewSpread(ast.NewIdentifier1("xs")),
+			ast.NewKeywordArg(tokens.NewIdentToken("b"), ast.NewLiteral(tokens.NewIntegerToken(2))),
+		})), program.Stmts[1])
+	}
+
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{`fn f(a, b, c, d, e, f, g, h) {}`, ""},
+		{`fn f(a, b, c, d, e, f, g, h, i) {}`, "cannot have more than 8 parameters"},
+		{`fn f(a, b, c, d, e, f, g, h, ...i) {}`, "cannot have more than 8 parameters"},
+		{`fn f(a = 1, b) {}`, "parameter b without default value follows a parameter with default value"},
+		{`fn f(...a, b) {}`, "rest parameter a must be the last parameter"},
+		{`f(a: 1, 2);`, "positional argument follows keyword argument"},
+		{`f(a: 1, a: 2);`, "duplicate keyword argument: a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			tokenList, err := lexer.TokensFromInput(tt.input)
+			assert.Nil(t, err)
+
+			_, err = NewParser(tokenList).ParseProgram()
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestParseForWithoutClauses(t *testing.T) {
+	tokenList, err := lexer.TokensFromInput(`for (;;) break;`)
+	assert.Nil(t, err)
+
+	program, err := NewParser(tokenList).ParseProgram()
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(program.Stmts)) {
+		trueLiteral, _ := ast.NewLiteral1(true)
+		assert.Equal(t, ast.NewForStmt(nil, nil, nil, ast.NewBreakStmt()), program.Stmts[0])
+		assert.Equal(t, ast.NewWhileStmt(trueLiteral, ast.NewBreakStmt()), program.Stmts[0].(*ast.ForStmt).Loop)
+	}
+}
+
+func TestParseBreakOutsideLoop(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{name: "top level", input: `break;`, err: "can not break outside a loop"},
+		{name: "if", input: `if (x) { break; }`, err: "can not break outside a loop"},
+		{name: "function in loop", input: `while (x) { fn f() { break; } }`, err: "can not break outside a loop"},
+		{name: "loop in function", input: `fn f() { for (x in xs) { if (x) break; } }`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenList, err := lexer.TokensFromInput(tt.input)
+			assert.Nil(t, err)
+
+			_, err = NewParser(tokenList).ParseProgram()
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -2,13 +2,13 @@ package repl
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 
 	"github.com/forfd8960/simpleinterpreter/eval"
 	"github.com/forfd8960/simpleinterpreter/lexer"
-	"github.com/forfd8960/simpleinterpreter/object"
 	"github.com/forfd8960/simpleinterpreter/parser"
 )
 
@@ -17,7 +17,8 @@ const PROMT = ">>%s"
 func Start(in io.Reader, out io.Writer) {
 	scanner := bufio.NewScanner(in)
 
-	env := object.NewEnvironment()
+	env := eval.NewEnvironment(nil)
+	defer eval.Close(env)
 	for {
 		fmt.Printf(PROMT, " ")
 		scanned := scanner.Scan()
@@ -52,7 +53,8 @@ func Start(in io.Reader, out io.Writer) {
 	}
 }
 
-func RunScript(file string) error {
+// RunScript runs the script file with the interpreter configured by opts
+func RunScript(file string, opts *eval.Options) error {
 	bs, err := os.ReadFile(file)
 	if err != nil {
 		return err
@@ -71,10 +73,14 @@ func RunScript(file string) error {
 		return err
 	}
 
-	env := object.NewEnvironment()
+	env := eval.NewScriptEnvironment(file, opts)
+	defer eval.Close(env)
 	result, err := eval.Eval(program, env)
 	if err != nil {
-		fmt.Println("eval err: ", err)
+		var exit *eval.ExitError
+		if !errors.As(err, &exit) {
+			fmt.Println("eval err: ", err)
+		}
 		return err
 	}
 
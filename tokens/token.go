@@ -2,24 +2,33 @@ package tokens
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
 const (
 	KWLet    = "let"
+	KWConst  = "const"
 	KWReturn = "return"
+	KWYield  = "yield"
 	KWPrint  = "print"
 	KWClass  = "class"
 	KWThis   = "this"
 	KWFn     = "fn"
 	KWIf     = "if"
 	KWElse   = "else"
+	KWMatch  = "match"
 	KwFor    = "for"
+	KwIn     = "in"
 	KwWhile  = "while"
 	KwBreak  = "break"
 	KWTrue   = "true"
 	KWFlase  = "false"
 	KWNull   = "null"
+	KWImport = "import"
+	KWFrom   = "from"
+	KWAs     = "as"
+	KWExport = "export"
 )
 
 var (
@@ -34,17 +43,25 @@ var (
 
 var keywords = map[string]TokenType{
 	KWLet:    LET,
+	KWConst:  CONST,
 	KWClass:  CLASS,
 	KWThis:   THIS,
 	KWFn:     FUNCTION,
 	KWIf:     IF,
 	KWElse:   ELSE,
+	KWMatch:  MATCH,
 	KwFor:    FOR,
+	KwIn:     IN,
 	KWReturn: RETURN,
+	KWYield:  YIELD,
 	KWTrue:   TRUE,
 	KWFlase:  FALSE,
 	KWPrint:  PRINT,
-	KWNull:   NIL,
+	KWNull:   NULL,
+	KWImport: IMPORT,
+	KWFrom:   FROM,
+	KWAs:     AS,
+	KWExport: EXPORT,
 }
 
 var keyword2Token = map[string]*Token{
@@ -53,6 +70,11 @@ var keyword2Token = map[string]*Token{
 		Literal: "let",
 		Value:   "let",
 	},
+	KWConst: {
+		TkType:  CONST,
+		Literal: "const",
+		Value:   "const",
+	},
 	KWClass: {
 		TkType:  CLASS,
 		Literal: "class",
@@ -78,6 +100,11 @@ var keyword2Token = map[string]*Token{
 		Literal: "for",
 		Value:   "for",
 	},
+	KwIn: {
+		TkType:  IN,
+		Literal: "in",
+		Value:   "in",
+	},
 	KwWhile: {
 		TkType:  WHILE,
 		Literal: "while",
@@ -93,11 +120,21 @@ var keyword2Token = map[string]*Token{
 		Literal: "else",
 		Value:   "else",
 	},
+	KWMatch: {
+		TkType:  MATCH,
+		Literal: "match",
+		Value:   "match",
+	},
 	KWReturn: {
 		TkType:  RETURN,
 		Literal: "return",
 		Value:   "return",
 	},
+	KWYield: {
+		TkType:  YIELD,
+		Literal: "yield",
+		Value:   "yield",
+	},
 	KWPrint: {
 		TkType:  PRINT,
 		Literal: "print",
@@ -114,10 +151,30 @@ var keyword2Token = map[string]*Token{
 		Value:   false,
 	},
 	KWNull: {
-		TkType:  NIL,
+		TkType:  NULL,
 		Literal: "null",
 		Value:   nil,
 	},
+	KWImport: {
+		TkType:  IMPORT,
+		Literal: "import",
+		Value:   "import",
+	},
+	KWFrom: {
+		TkType:  FROM,
+		Literal: "from",
+		Value:   "from",
+	},
+	KWAs: {
+		TkType:  AS,
+		Literal: "as",
+		Value:   "as",
+	},
+	KWExport: {
+		TkType:  EXPORT,
+		Literal: "export",
+		Value:   "export",
+	},
 }
 
 type Token struct {
@@ -126,6 +183,16 @@ type Token struct {
 	Value   interface{}
 }
 
+// Position is where a token starts in the input, line and column start from 1, and column counts runes
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (pos Position) String() string {
+	return fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+}
+
 func NewToken(tkType TokenType, literal string, value interface{}) *Token {
 	return &Token{
 		TkType:  tkType,
@@ -142,6 +209,10 @@ func NewIntegerToken(v int64) *Token {
 	return NewToken(INTEGER, fmt.Sprintf("%d", v), v)
 }
 
+func NewFloatToken(v float64) *Token {
+	return NewToken(FLOAT, strconv.FormatFloat(v, 'f', -1, 64), v)
+}
+
 func NewBoolToken(v bool) *Token {
 	tkTp := TRUE
 	if !v {
@@ -178,3 +249,27 @@ func LookupTokenByIdent(ident string) *Token {
 
 	return nil
 }
+
+// Quote returns s as a string literal, using the escape sequences of the lexer: \" \\ \n \t \r
+func Quote(s string) string {
+	sb := &strings.Builder{}
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
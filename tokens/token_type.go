@@ -9,6 +9,7 @@ const (
 
 	IDENT
 	INTEGER
+	FLOAT
 	STRING
 	ASSIGN   // =
 	PLUS     // +
@@ -19,11 +20,17 @@ const (
 	ASTERISK // *
 	POW      // **
 	SLASH    // /
+	PERCENT  // %
 	LT       // <
 	LTEQ     // <=
 	GT       // >
 	GTEQ     // >=
 
+	PLUSEQ     // +=
+	MINUSEQ    // -=
+	ASTERISKEQ // *=
+	SLASHEQ    // /=
+
 	EQUAL    // ==
 	NOTEQUAL // !=
 
@@ -32,7 +39,15 @@ const (
 
 	COMMA     // ,
 	SEMICOLON // ;
+	COLON     // :
 	DOT       // .
+	DOTDOT    // ..
+	DOTDOTEQ  // ..=
+	ELLIPSIS  // ...
+	ARROW     // =>
+	QUESTION  // ?
+	DQUESTION // ??
+	QDOT      // ?.
 
 	LPRARENT   // (
 	RPARENT    // )
@@ -45,18 +60,27 @@ const (
 	THIS     // this
 	FUNCTION // fn
 	LET      // let
+	CONST    // const
 	IF       // if
 	ELSE     // else
+	MATCH    // match
 	RETURN   // return
+	YIELD    // yield
 	TRUE     // true
 	FALSE    // false
-	NIL      // nil
+	NULL     // null
 
 	FOR   // for
+	IN    // in
 	WHILE // while
 	PRINT // print()
 	BREAK // break
 
+	IMPORT // import
+	FROM   // from
+	AS     // as
+	EXPORT // export
+
 	WS // space, \r \t \n
 )
 
@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _TokenTypeName = "ILLEGALEOFIDENTINTEGERSTRINGASSIGNPLUSDPlusDMinusMINUSBANGASTERISKPOWSLASHLTLTEQGTGTEQEQUALNOTEQUALORANDCOMMASEMICOLONDOTLPRARENTRPARENTLBRACERBRACELSQBRACKETRSQBRACKETCLASSTHISFUNCTIONLETIFELSERETURNTRUEFALSENILFORWHILEPRINTBREAKWS"
+const _TokenTypeName = "ILLEGALEOFIDENTINTEGERFLOATSTRINGASSIGNPLUSDPlusDMinusMINUSBANGASTERISKPOWSLASHPERCENTLTLTEQGTGTEQPLUSEQMINUSEQASTERISKEQSLASHEQEQUALNOTEQUALORANDCOMMASEMICOLONCOLONDOTDOTDOTDOTDOTEQELLIPSISARROWQUESTIONDQUESTIONQDOTLPRARENTRPARENTLBRACERBRACELSQBRACKETRSQBRACKETCLASSTHISFUNCTIONLETCONSTIFELSEMATCHRETURNYIELDTRUEFALSENULLFORINWHILEPRINTBREAKIMPORTFROMASEXPORTWS"
 
-var _TokenTypeIndex = [...]uint8{0, 7, 10, 15, 22, 28, 34, 38, 43, 49, 54, 58, 66, 69, 74, 76, 80, 82, 86, 91, 99, 101, 104, 109, 118, 121, 129, 136, 142, 148, 158, 168, 173, 177, 185, 188, 190, 194, 200, 204, 209, 212, 215, 220, 225, 230, 232}
+var _TokenTypeIndex = [...]uint16{0, 7, 10, 15, 22, 27, 33, 39, 43, 48, 54, 59, 63, 71, 74, 79, 86, 88, 92, 94, 98, 104, 111, 121, 128, 133, 141, 143, 146, 151, 160, 165, 168, 174, 182, 190, 195, 203, 212, 216, 224, 231, 237, 243, 253, 263, 268, 272, 280, 283, 288, 290, 294, 299, 305, 310, 314, 319, 323, 326, 328, 333, 338, 343, 349, 353, 355, 361, 363}
 
-const _TokenTypeLowerName = "illegaleofidentintegerstringassignplusdplusdminusminusbangasteriskpowslashltlteqgtgteqequalnotequalorandcommasemicolondotlprarentrparentlbracerbracelsqbracketrsqbracketclassthisfunctionletifelsereturntruefalsenilforwhileprintbreakws"
+const _TokenTypeLowerName = "illegaleofidentintegerfloatstringassignplusdplusdminusminusbangasteriskpowslashpercentltlteqgtgteqpluseqminuseqasteriskeqslasheqequalnotequalorandcommasemicoloncolondotdotdotdotdoteqellipsisarrowquestiondquestionqdotlprarentrparentlbracerbracelsqbracketrsqbracketclassthisfunctionletconstifelsematchreturnyieldtruefalsenullforinwhileprintbreakimportfromasexportws"
 
 func (i TokenType) String() string {
 	if i < 0 || i >= TokenType(len(_TokenTypeIndex)-1) {
@@ -28,51 +28,73 @@ func _TokenTypeNoOp() {
 	_ = x[EOF-(1)]
 	_ = x[IDENT-(2)]
 	_ = x[INTEGER-(3)]
-	_ = x[STRING-(4)]
-	_ = x[ASSIGN-(5)]
-	_ = x[PLUS-(6)]
-	_ = x[DPlus-(7)]
-	_ = x[DMinus-(8)]
-	_ = x[MINUS-(9)]
-	_ = x[BANG-(10)]
-	_ = x[ASTERISK-(11)]
-	_ = x[POW-(12)]
-	_ = x[SLASH-(13)]
-	_ = x[LT-(14)]
-	_ = x[LTEQ-(15)]
-	_ = x[GT-(16)]
-	_ = x[GTEQ-(17)]
-	_ = x[EQUAL-(18)]
-	_ = x[NOTEQUAL-(19)]
-	_ = x[OR-(20)]
-	_ = x[AND-(21)]
-	_ = x[COMMA-(22)]
-	_ = x[SEMICOLON-(23)]
-	_ = x[DOT-(24)]
-	_ = x[LPRARENT-(25)]
-	_ = x[RPARENT-(26)]
-	_ = x[LBRACE-(27)]
-	_ = x[RBRACE-(28)]
-	_ = x[LSQBRACKET-(29)]
-	_ = x[RSQBRACKET-(30)]
-	_ = x[CLASS-(31)]
-	_ = x[THIS-(32)]
-	_ = x[FUNCTION-(33)]
-	_ = x[LET-(34)]
-	_ = x[IF-(35)]
-	_ = x[ELSE-(36)]
-	_ = x[RETURN-(37)]
-	_ = x[TRUE-(38)]
-	_ = x[FALSE-(39)]
-	_ = x[NIL-(40)]
-	_ = x[FOR-(41)]
-	_ = x[WHILE-(42)]
-	_ = x[PRINT-(43)]
-	_ = x[BREAK-(44)]
-	_ = x[WS-(45)]
+	_ = x[FLOAT-(4)]
+	_ = x[STRING-(5)]
+	_ = x[ASSIGN-(6)]
+	_ = x[PLUS-(7)]
+	_ = x[DPlus-(8)]
+	_ = x[DMinus-(9)]
+	_ = x[MINUS-(10)]
+	_ = x[BANG-(11)]
+	_ = x[ASTERISK-(12)]
+	_ = x[POW-(13)]
+	_ = x[SLASH-(14)]
+	_ = x[PERCENT-(15)]
+	_ = x[LT-(16)]
+	_ = x[LTEQ-(17)]
+	_ = x[GT-(18)]
+	_ = x[GTEQ-(19)]
+	_ = x[PLUSEQ-(20)]
+	_ = x[MINUSEQ-(21)]
+	_ = x[ASTERISKEQ-(22)]
+	_ = x[SLASHEQ-(23)]
+	_ = x[EQUAL-(24)]
+	_ = x[NOTEQUAL-(25)]
+	_ = x[OR-(26)]
+	_ = x[AND-(27)]
+	_ = x[COMMA-(28)]
+	_ = x[SEMICOLON-(29)]
+	_ = x[COLON-(30)]
+	_ = x[DOT-(31)]
+	_ = x[DOTDOT-(32)]
+	_ = x[DOTDOTEQ-(33)]
+	_ = x[ELLIPSIS-(34)]
+	_ = x[ARROW-(35)]
+	_ = x[QUESTION-(36)]
+	_ = x[DQUESTION-(37)]
+	_ = x[QDOT-(38)]
+	_ = x[LPRARENT-(39)]
+	_ = x[RPARENT-(40)]
+	_ = x[LBRACE-(41)]
+	_ = x[RBRACE-(42)]
+	_ = x[LSQBRACKET-(43)]
+	_ = x[RSQBRACKET-(44)]
+	_ = x[CLASS-(45)]
+	_ = x[THIS-(46)]
+	_ = x[FUNCTION-(47)]
+	_ = x[LET-(48)]
+	_ = x[CONST-(49)]
+	_ = x[IF-(50)]
+	_ = x[ELSE-(51)]
+	_ = x[MATCH-(52)]
+	_ = x[RETURN-(53)]
+	_ = x[YIELD-(54)]
+	_ = x[TRUE-(55)]
+	_ = x[FALSE-(56)]
+	_ = x[NULL-(57)]
+	_ = x[FOR-(58)]
+	_ = x[IN-(59)]
+	_ = x[WHILE-(60)]
+	_ = x[PRINT-(61)]
+	_ = x[BREAK-(62)]
+	_ = x[IMPORT-(63)]
+	_ = x[FROM-(64)]
+	_ = x[AS-(65)]
+	_ = x[EXPORT-(66)]
+	_ = x[WS-(67)]
 }
 
-var _TokenTypeValues = []TokenType{ILLEGAL, EOF, IDENT, INTEGER, STRING, ASSIGN, PLUS, DPlus, DMinus, MINUS, BANG, ASTERISK, POW, SLASH, LT, LTEQ, GT, GTEQ, EQUAL, NOTEQUAL, OR, AND, COMMA, SEMICOLON, DOT, LPRARENT, RPARENT, LBRACE, RBRACE, LSQBRACKET, RSQBRACKET, CLASS, THIS, FUNCTION, LET, IF, ELSE, RETURN, TRUE, FALSE, NIL, FOR, WHILE, PRINT, BREAK, WS}
+var _TokenTypeValues = []TokenType{ILLEGAL, EOF, IDENT, INTEGER, FLOAT, STRING, ASSIGN, PLUS, DPlus, DMinus, MINUS, BANG, ASTERISK, POW, SLASH, PERCENT, LT, LTEQ, GT, GTEQ, PLUSEQ, MINUSEQ, ASTERISKEQ, SLASHEQ, EQUAL, NOTEQUAL, OR, AND, COMMA, SEMICOLON, COLON, DOT, DOTDOT, DOTDOTEQ, ELLIPSIS, ARROW, QUESTION, DQUESTION, QDOT, LPRARENT, RPARENT, LBRACE, RBRACE, LSQBRACKET, RSQBRACKET, CLASS, THIS, FUNCTION, LET, CONST, IF, ELSE, MATCH, RETURN, YIELD, TRUE, FALSE, NULL, FOR, IN, WHILE, PRINT, BREAK, IMPORT, FROM, AS, EXPORT, WS}
 
 var _TokenTypeNameToValueMap = map[string]TokenType{
 	_TokenTypeName[0:7]:          ILLEGAL,
@@ -83,90 +105,134 @@ var _TokenTypeNameToValueMap = map[string]TokenType{
 	_TokenTypeLowerName[10:15]:   IDENT,
 	_TokenTypeName[15:22]:        INTEGER,
 	_TokenTypeLowerName[15:22]:   INTEGER,
-	_TokenTypeName[22:28]:        STRING,
-	_TokenTypeLowerName[22:28]:   STRING,
-	_TokenTypeName[28:34]:        ASSIGN,
-	_TokenTypeLowerName[28:34]:   ASSIGN,
-	_TokenTypeName[34:38]:        PLUS,
-	_TokenTypeLowerName[34:38]:   PLUS,
-	_TokenTypeName[38:43]:        DPlus,
-	_TokenTypeLowerName[38:43]:   DPlus,
-	_TokenTypeName[43:49]:        DMinus,
-	_TokenTypeLowerName[43:49]:   DMinus,
-	_TokenTypeName[49:54]:        MINUS,
-	_TokenTypeLowerName[49:54]:   MINUS,
-	_TokenTypeName[54:58]:        BANG,
-	_TokenTypeLowerName[54:58]:   BANG,
-	_TokenTypeName[58:66]:        ASTERISK,
-	_TokenTypeLowerName[58:66]:   ASTERISK,
-	_TokenTypeName[66:69]:        POW,
-	_TokenTypeLowerName[66:69]:   POW,
-	_TokenTypeName[69:74]:        SLASH,
-	_TokenTypeLowerName[69:74]:   SLASH,
-	_TokenTypeName[74:76]:        LT,
-	_TokenTypeLowerName[74:76]:   LT,
-	_TokenTypeName[76:80]:        LTEQ,
-	_TokenTypeLowerName[76:80]:   LTEQ,
-	_TokenTypeName[80:82]:        GT,
-	_TokenTypeLowerName[80:82]:   GT,
-	_TokenTypeName[82:86]:        GTEQ,
-	_TokenTypeLowerName[82:86]:   GTEQ,
-	_TokenTypeName[86:91]:        EQUAL,
-	_TokenTypeLowerName[86:91]:   EQUAL,
-	_TokenTypeName[91:99]:        NOTEQUAL,
-	_TokenTypeLowerName[91:99]:   NOTEQUAL,
-	_TokenTypeName[99:101]:       OR,
-	_TokenTypeLowerName[99:101]:  OR,
-	_TokenTypeName[101:104]:      AND,
-	_TokenTypeLowerName[101:104]: AND,
-	_TokenTypeName[104:109]:      COMMA,
-	_TokenTypeLowerName[104:109]: COMMA,
-	_TokenTypeName[109:118]:      SEMICOLON,
-	_TokenTypeLowerName[109:118]: SEMICOLON,
-	_TokenTypeName[118:121]:      DOT,
-	_TokenTypeLowerName[118:121]: DOT,
-	_TokenTypeName[121:129]:      LPRARENT,
-	_TokenTypeLowerName[121:129]: LPRARENT,
-	_TokenTypeName[129:136]:      RPARENT,
-	_TokenTypeLowerName[129:136]: RPARENT,
-	_TokenTypeName[136:142]:      LBRACE,
-	_TokenTypeLowerName[136:142]: LBRACE,
-	_TokenTypeName[142:148]:      RBRACE,
-	_TokenTypeLowerName[142:148]: RBRACE,
-	_TokenTypeName[148:158]:      LSQBRACKET,
-	_TokenTypeLowerName[148:158]: LSQBRACKET,
-	_TokenTypeName[158:168]:      RSQBRACKET,
-	_TokenTypeLowerName[158:168]: RSQBRACKET,
-	_TokenTypeName[168:173]:      CLASS,
-	_TokenTypeLowerName[168:173]: CLASS,
-	_TokenTypeName[173:177]:      THIS,
-	_TokenTypeLowerName[173:177]: THIS,
-	_TokenTypeName[177:185]:      FUNCTION,
-	_TokenTypeLowerName[177:185]: FUNCTION,
-	_TokenTypeName[185:188]:      LET,
-	_TokenTypeLowerName[185:188]: LET,
-	_TokenTypeName[188:190]:      IF,
-	_TokenTypeLowerName[188:190]: IF,
-	_TokenTypeName[190:194]:      ELSE,
-	_TokenTypeLowerName[190:194]: ELSE,
-	_TokenTypeName[194:200]:      RETURN,
-	_TokenTypeLowerName[194:200]: RETURN,
-	_TokenTypeName[200:204]:      TRUE,
-	_TokenTypeLowerName[200:204]: TRUE,
-	_TokenTypeName[204:209]:      FALSE,
-	_TokenTypeLowerName[204:209]: FALSE,
-	_TokenTypeName[209:212]:      NIL,
-	_TokenTypeLowerName[209:212]: NIL,
-	_TokenTypeName[212:215]:      FOR,
-	_TokenTypeLowerName[212:215]: FOR,
-	_TokenTypeName[215:220]:      WHILE,
-	_TokenTypeLowerName[215:220]: WHILE,
-	_TokenTypeName[220:225]:      PRINT,
-	_TokenTypeLowerName[220:225]: PRINT,
-	_TokenTypeName[225:230]:      BREAK,
-	_TokenTypeLowerName[225:230]: BREAK,
-	_TokenTypeName[230:232]:      WS,
-	_TokenTypeLowerName[230:232]: WS,
+	_TokenTypeName[22:27]:        FLOAT,
+	_TokenTypeLowerName[22:27]:   FLOAT,
+	_TokenTypeName[27:33]:        STRING,
+	_TokenTypeLowerName[27:33]:   STRING,
+	_TokenTypeName[33:39]:        ASSIGN,
+	_TokenTypeLowerName[33:39]:   ASSIGN,
+	_TokenTypeName[39:43]:        PLUS,
+	_TokenTypeLowerName[39:43]:   PLUS,
+	_TokenTypeName[43:48]:        DPlus,
+	_TokenTypeLowerName[43:48]:   DPlus,
+	_TokenTypeName[48:54]:        DMinus,
+	_TokenTypeLowerName[48:54]:   DMinus,
+	_TokenTypeName[54:59]:        MINUS,
+	_TokenTypeLowerName[54:59]:   MINUS,
+	_TokenTypeName[59:63]:        BANG,
+	_TokenTypeLowerName[59:63]:   BANG,
+	_TokenTypeName[63:71]:        ASTERISK,
+	_TokenTypeLowerName[63:71]:   ASTERISK,
+	_TokenTypeName[71:74]:        POW,
+	_TokenTypeLowerName[71:74]:   POW,
+	_TokenTypeName[74:79]:        SLASH,
+	_TokenTypeLowerName[74:79]:   SLASH,
+	_TokenTypeName[79:86]:        PERCENT,
+	_TokenTypeLowerName[79:86]:   PERCENT,
+	_TokenTypeName[86:88]:        LT,
+	_TokenTypeLowerName[86:88]:   LT,
+	_TokenTypeName[88:92]:        LTEQ,
+	_TokenTypeLowerName[88:92]:   LTEQ,
+	_TokenTypeName[92:94]:        GT,
+	_TokenTypeLowerName[92:94]:   GT,
+	_TokenTypeName[94:98]:        GTEQ,
+	_TokenTypeLowerName[94:98]:   GTEQ,
+	_TokenTypeName[98:104]:       PLUSEQ,
+	_TokenTypeLowerName[98:104]:  PLUSEQ,
+	_TokenTypeName[104:111]:      MINUSEQ,
+	_TokenTypeLowerName[104:111]: MINUSEQ,
+	_TokenTypeName[111:121]:      ASTERISKEQ,
+	_TokenTypeLowerName[111:121]: ASTERISKEQ,
+	_TokenTypeName[121:128]:      SLASHEQ,
+	_TokenTypeLowerName[121:128]: SLASHEQ,
+	_TokenTypeName[128:133]:      EQUAL,
+	_TokenTypeLowerName[128:133]: EQUAL,
+	_TokenTypeName[133:141]:      NOTEQUAL,
+	_TokenTypeLowerName[133:141]: NOTEQUAL,
+	_TokenTypeName[141:143]:      OR,
+	_TokenTypeLowerName[141:143]: OR,
+	_TokenTypeName[143:146]:      AND,
+	_TokenTypeLowerName[143:146]: AND,
+	_TokenTypeName[146:151]:      COMMA,
+	_TokenTypeLowerName[146:151]: COMMA,
+	_TokenTypeName[151:160]:      SEMICOLON,
+	_TokenTypeLowerName[151:160]: SEMICOLON,
+	_TokenTypeName[160:165]:      COLON,
+	_TokenTypeLowerName[160:165]: COLON,
+	_TokenTypeName[165:168]:      DOT,
+	_TokenTypeLowerName[165:168]: DOT,
+	_TokenTypeName[168:174]:      DOTDOT,
+	_TokenTypeLowerName[168:174]: DOTDOT,
+	_TokenTypeName[174:182]:      DOTDOTEQ,
+	_TokenTypeLowerName[174:182]: DOTDOTEQ,
+	_TokenTypeName[182:190]:      ELLIPSIS,
+	_TokenTypeLowerName[182:190]: ELLIPSIS,
+	_TokenTypeName[190:195]:      ARROW,
+	_TokenTypeLowerName[190:195]: ARROW,
+	_TokenTypeName[195:203]:      QUESTION,
+	_TokenTypeLowerName[195:203]: QUESTION,
+	_TokenTypeName[203:212]:      DQUESTION,
+	_TokenTypeLowerName[203:212]: DQUESTION,
+	_TokenTypeName[212:216]:      QDOT,
+	_TokenTypeLowerName[212:216]: QDOT,
+	_TokenTypeName[216:224]:      LPRARENT,
+	_TokenTypeLowerName[216:224]: LPRARENT,
+	_TokenTypeName[224:231]:      RPARENT,
+	_TokenTypeLowerName[224:231]: RPARENT,
+	_TokenTypeName[231:237]:      LBRACE,
+	_TokenTypeLowerName[231:237]: LBRACE,
+	_TokenTypeName[237:243]:      RBRACE,
+	_TokenTypeLowerName[237:243]: RBRACE,
+	_TokenTypeName[243:253]:      LSQBRACKET,
+	_TokenTypeLowerName[243:253]: LSQBRACKET,
+	_TokenTypeName[253:263]:      RSQBRACKET,
+	_TokenTypeLowerName[253:263]: RSQBRACKET,
+	_TokenTypeName[263:268]:      CLASS,
+	_TokenTypeLowerName[263:268]: CLASS,
+	_TokenTypeName[268:272]:      THIS,
+	_TokenTypeLowerName[268:272]: THIS,
+	_TokenTypeName[272:280]:      FUNCTION,
+	_TokenTypeLowerName[272:280]: FUNCTION,
+	_TokenTypeName[280:283]:      LET,
+	_TokenTypeLowerName[280:283]: LET,
+	_TokenTypeName[283:288]:      CONST,
+	_TokenTypeLowerName[283:288]: CONST,
+	_TokenTypeName[288:290]:      IF,
+	_TokenTypeLowerName[288:290]: IF,
+	_TokenTypeName[290:294]:      ELSE,
+	_TokenTypeLowerName[290:294]: ELSE,
+	_TokenTypeName[294:299]:      MATCH,
+	_TokenTypeLowerName[294:299]: MATCH,
+	_TokenTypeName[299:305]:      RETURN,
+	_TokenTypeLowerName[299:305]: RETURN,
+	_TokenTypeName[305:310]:      YIELD,
+	_TokenTypeLowerName[305:310]: YIELD,
+	_TokenTypeName[310:314]:      TRUE,
+	_TokenTypeLowerName[310:314]: TRUE,
+	_TokenTypeName[314:319]:      FALSE,
+	_TokenTypeLowerName[314:319]: FALSE,
+	_TokenTypeName[319:323]:      NULL,
+	_TokenTypeLowerName[319:323]: NULL,
+	_TokenTypeName[323:326]:      FOR,
+	_TokenTypeLowerName[323:326]: FOR,
+	_TokenTypeName[326:328]:      IN,
+	_TokenTypeLowerName[326:328]: IN,
+	_TokenTypeName[328:333]:      WHILE,
+	_TokenTypeLowerName[328:333]: WHILE,
+	_TokenTypeName[333:338]:      PRINT,
+	_TokenTypeLowerName[333:338]: PRINT,
+	_TokenTypeName[338:343]:      BREAK,
+	_TokenTypeLowerName[338:343]: BREAK,
+	_TokenTypeName[343:349]:      IMPORT,
+	_TokenTypeLowerName[343:349]: IMPORT,
+	_TokenTypeName[349:353]:      FROM,
+	_TokenTypeLowerName[349:353]: FROM,
+	_TokenTypeName[353:355]:      AS,
+	_TokenTypeLowerName[353:355]: AS,
+	_TokenTypeName[355:361]:      EXPORT,
+	_TokenTypeLowerName[355:361]: EXPORT,
+	_TokenTypeName[361:363]:      WS,
+	_TokenTypeLowerName[361:363]: WS,
 }
 
 var _TokenTypeNames = []string{
@@ -174,48 +240,70 @@ var _TokenTypeNames = []string{
 	_TokenTypeName[7:10],
 	_TokenTypeName[10:15],
 	_TokenTypeName[15:22],
-	_TokenTypeName[22:28],
-	_TokenTypeName[28:34],
-	_TokenTypeName[34:38],
-	_TokenTypeName[38:43],
-	_TokenTypeName[43:49],
-	_TokenTypeName[49:54],
-	_TokenTypeName[54:58],
-	_TokenTypeName[58:66],
-	_TokenTypeName[66:69],
-	_TokenTypeName[69:74],
-	_TokenTypeName[74:76],
-	_TokenTypeName[76:80],
-	_TokenTypeName[80:82],
-	_TokenTypeName[82:86],
-	_TokenTypeName[86:91],
-	_TokenTypeName[91:99],
-	_TokenTypeName[99:101],
-	_TokenTypeName[101:104],
-	_TokenTypeName[104:109],
-	_TokenTypeName[109:118],
-	_TokenTypeName[118:121],
-	_TokenTypeName[121:129],
-	_TokenTypeName[129:136],
-	_TokenTypeName[136:142],
-	_TokenTypeName[142:148],
-	_TokenTypeName[148:158],
-	_TokenTypeName[158:168],
-	_TokenTypeName[168:173],
-	_TokenTypeName[173:177],
-	_TokenTypeName[177:185],
-	_TokenTypeName[185:188],
-	_TokenTypeName[188:190],
-	_TokenTypeName[190:194],
-	_TokenTypeName[194:200],
-	_TokenTypeName[200:204],
-	_TokenTypeName[204:209],
-	_TokenTypeName[209:212],
-	_TokenTypeName[212:215],
-	_TokenTypeName[215:220],
-	_TokenTypeName[220:225],
-	_TokenTypeName[225:230],
-	_TokenTypeName[230:232],
+	_TokenTypeName[22:27],
+	_TokenTypeName[27:33],
+	_TokenTypeName[33:39],
+	_TokenTypeName[39:43],
+	_TokenTypeName[43:48],
+	_TokenTypeName[48:54],
+	_TokenTypeName[54:59],
+	_TokenTypeName[59:63],
+	_TokenTypeName[63:71],
+	_TokenTypeName[71:74],
+	_TokenTypeName[74:79],
+	_TokenTypeName[79:86],
+	_TokenTypeName[86:88],
+	_TokenTypeName[88:92],
+	_TokenTypeName[92:94],
+	_TokenTypeName[94:98],
+	_TokenTypeName[98:104],
+	_TokenTypeName[104:111],
+	_TokenTypeName[111:121],
+	_TokenTypeName[121:128],
+	_TokenTypeName[128:133],
+	_TokenTypeName[133:141],
+	_TokenTypeName[141:143],
+	_TokenTypeName[143:146],
+	_TokenTypeName[146:151],
+	_TokenTypeName[151:160],
+	_TokenTypeName[160:165],
+	_TokenTypeName[165:168],
+	_TokenTypeName[168:174],
+	_TokenTypeName[174:182],
+	_TokenTypeName[182:190],
+	_TokenTypeName[190:195],
+	_TokenTypeName[195:203],
+	_TokenTypeName[203:212],
+	_TokenTypeName[212:216],
+	_TokenTypeName[216:224],
+	_TokenTypeName[224:231],
+	_TokenTypeName[231:237],
+	_TokenTypeName[237:243],
+	_TokenTypeName[243:253],
+	_TokenTypeName[253:263],
+	_TokenTypeName[263:268],
+	_TokenTypeName[268:272],
+	_TokenTypeName[272:280],
+	_TokenTypeName[280:283],
+	_TokenTypeName[283:288],
+	_TokenTypeName[288:290],
+	_TokenTypeName[290:294],
+	_TokenTypeName[294:299],
+	_TokenTypeName[299:305],
+	_TokenTypeName[305:310],
+	_TokenTypeName[310:314],
+	_TokenTypeName[314:319],
+	_TokenTypeName[319:323],
+	_TokenTypeName[323:326],
+	_TokenTypeName[326:328],
+	_TokenTypeName[328:333],
+	_TokenTypeName[333:338],
+	_TokenTypeName[338:343],
+	_TokenTypeName[343:349],
+	_TokenTypeName[349:353],
+	_TokenTypeName[353:355],
+	_TokenTypeName[355:361],
+	_TokenTypeName[361:363],
 }
 
 // TokenTypeString retrieves an enum value from the enum constants string name.